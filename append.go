@@ -0,0 +1,93 @@
+package snowflake
+
+import (
+	"encoding/base64"
+	"strconv"
+)
+
+// The Append methods write an encoding of the snowflake ID to the end of dst
+// and return the extended buffer, like the strconv.Append functions. They do
+// not allocate when dst has enough spare capacity, which makes them suitable
+// for hot paths such as logging.
+
+// AppendString appends the decimal string of the snowflake ID to dst
+func (sid ID) AppendString(dst []byte) []byte {
+	return strconv.AppendInt(dst, int64(sid), 10)
+}
+
+// AppendText implements encoding.TextAppender, appending the decimal string
+// of the snowflake ID to b
+func (sid ID) AppendText(b []byte) ([]byte, error) {
+	return sid.AppendString(b), nil
+}
+
+// AppendBinary implements encoding.BinaryAppender, appending the 8 big endian
+// bytes of the snowflake ID to b
+func (sid ID) AppendBinary(b []byte) ([]byte, error) {
+	u := uint64(sid)
+	return append(b, byte(u>>56), byte(u>>48), byte(u>>40), byte(u>>32), byte(u>>24), byte(u>>16), byte(u>>8), byte(u)), nil
+}
+
+// AppendBase32 appends the z-base-32 string of the snowflake ID to dst
+func (sid ID) AppendBase32(dst []byte) []byte {
+	return Base32Encoding.Append(dst, sid)
+}
+
+// AppendBase58 appends the base58 string of the snowflake ID to dst
+func (sid ID) AppendBase58(dst []byte) []byte {
+	return Base58Encoding.Append(dst, sid)
+}
+
+// AppendBase62 appends the base62 string of the snowflake ID to dst
+func (sid ID) AppendBase62(dst []byte) []byte {
+	return Base62Encoding.Append(dst, sid)
+}
+
+// AppendBase64 appends the base64 string of the decimal snowflake ID to dst,
+// like Base64
+func (sid ID) AppendBase64(dst []byte) []byte {
+	var b [20]byte
+	return appendBase64(dst, base64.StdEncoding, sid.AppendString(b[:0]))
+}
+
+// AppendRawBase64 appends the unpadded standard base64 string of the 8 big
+// endian bytes of the snowflake ID to dst, like RawBase64
+func (sid ID) AppendRawBase64(dst []byte) []byte {
+	b := sid.IntBytes()
+	return appendBase64(dst, base64.RawStdEncoding, b[:])
+}
+
+// AppendRawURLBase64 appends the unpadded URL-safe base64 string of the 8 big
+// endian bytes of the snowflake ID to dst, like RawURLBase64
+func (sid ID) AppendRawURLBase64(dst []byte) []byte {
+	b := sid.IntBytes()
+	return appendBase64(dst, base64.RawURLEncoding, b[:])
+}
+
+// appendBase appends u written with the digits of alphabet, most significant
+// digit first and without leading zeros
+func appendBase(dst []byte, u uint64, alphabet string) []byte {
+	var b [64]byte
+	base := uint64(len(alphabet))
+	i := len(b)
+	for u >= base {
+		i--
+		b[i] = alphabet[u%base]
+		u /= base
+	}
+	i--
+	b[i] = alphabet[u]
+	return append(dst, b[i:]...)
+}
+
+// appendBase64 appends src encoded with enc to dst
+func appendBase64(dst []byte, enc *base64.Encoding, src []byte) []byte {
+	n := enc.EncodedLen(len(src))
+	if cap(dst)-len(dst) < n {
+		grown := make([]byte, len(dst), 2*cap(dst)+n)
+		copy(grown, dst)
+		dst = grown
+	}
+	enc.Encode(dst[len(dst):len(dst)+n], src)
+	return dst[:len(dst)+n]
+}
@@ -0,0 +1,109 @@
+package snowflake
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+var appendEncodings = []struct {
+	name   string
+	append func(ID, []byte) []byte
+	encode func(ID) string
+}{
+	{"String", ID.AppendString, ID.String},
+	{"Base32", ID.AppendBase32, ID.Base32},
+	{"Base58", ID.AppendBase58, ID.Base58},
+	{"Base62", ID.AppendBase62, ID.Base62},
+	{"Base64", ID.AppendBase64, ID.Base64},
+	{"RawBase64", ID.AppendRawBase64, ID.RawBase64},
+	{"RawURLBase64", ID.AppendRawURLBase64, ID.RawURLBase64},
+}
+
+func TestAppendEncodings(t *testing.T) {
+	ids := []ID{0, 1, 57, 58, 13587, 1427970479175499776, math.MaxInt64}
+	for _, e := range appendEncodings {
+		for _, id := range ids {
+			b := e.append(id, []byte("id="))
+			if got, want := string(b), "id="+e.encode(id); got != want {
+				t.Errorf("%s: Append(%d) = %q, want %q", e.name, id, got, want)
+			}
+		}
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	id := ID(1427970479175499776)
+	b, err := id.AppendBinary([]byte{0xFF})
+	if err != nil {
+		t.Fatalf("error appending binary, %s", err)
+	}
+	want := id.IntBytes()
+	if !bytes.Equal(b[1:], want[:]) || b[0] != 0xFF {
+		t.Fatalf("AppendBinary %x != ff%x", b, want)
+	}
+
+	text, err := id.AppendText(nil)
+	if err != nil {
+		t.Fatalf("error appending text, %s", err)
+	}
+	if string(text) != id.String() {
+		t.Fatalf("AppendText %s != %s", text, id)
+	}
+}
+
+func TestAppendAllocs(t *testing.T) {
+	id := ID(1427970479175499776)
+	buf := make([]byte, 0, 64)
+	for _, e := range appendEncodings {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = e.append(id, buf[:0])
+		})
+		if allocs != 0 {
+			t.Errorf("%s: Append allocated %v times, want 0", e.name, allocs)
+		}
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = id.AppendBinary(buf[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("AppendBinary allocated %v times, want 0", allocs)
+	}
+}
+
+func benchmarkAppend(b *testing.B, append func(ID, []byte) []byte) {
+
+	node, _ := NewSnowflake(1, 1)
+	sf := node.NextVal()
+	buf := make([]byte, 0, 64)
+	if allocs := testing.AllocsPerRun(10, func() { buf = append(sf, buf[:0]) }); allocs != 0 {
+		b.Fatalf("append allocated %v times, want 0", allocs)
+	}
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		buf = append(sf, buf[:0])
+	}
+}
+
+func BenchmarkAppendString(b *testing.B) {
+	benchmarkAppend(b, ID.AppendString)
+}
+
+func BenchmarkAppendBase32(b *testing.B) {
+	benchmarkAppend(b, ID.AppendBase32)
+}
+
+func BenchmarkAppendBase58(b *testing.B) {
+	benchmarkAppend(b, ID.AppendBase58)
+}
+
+func BenchmarkAppendBase62(b *testing.B) {
+	benchmarkAppend(b, ID.AppendBase62)
+}
+
+func BenchmarkAppendRawURLBase64(b *testing.B) {
+	benchmarkAppend(b, ID.AppendRawURLBase64)
+}
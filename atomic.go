@@ -0,0 +1,120 @@
+package snowflake
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// AtomicSnowflake is a lock-free alternative to Snowflake. It packs the last
+// timestamp and sequence into a single uint64 and advances it with
+// compare-and-swap, so concurrent callers never block each other on a mutex.
+// It generates IDs with the same layout, epoch, clock and rollback semantics
+// as Snowflake.
+type AtomicSnowflake struct {
+	// state is the timestamp relative to the epoch shifted left by the
+	// sequence bits, ORed with the sequence. It is the first field so that it
+	// is 64-bit aligned on 32-bit platforms.
+	state uint64
+	node
+	sequenceBits uint
+}
+
+// NewAtomicSnowflake returns a new lock-free snowflake node
+func NewAtomicSnowflake(datacenterID, workerID int64, opts ...Option) (*AtomicSnowflake, error) {
+	return NewAtomicSnowflakeWithLayout(DefaultLayout, datacenterID, workerID, opts...)
+}
+
+// NewAtomicSnowflakeWithLayout returns a new lock-free snowflake node that
+// splits the bits of the generated IDs according to layout
+func NewAtomicSnowflakeWithLayout(layout Layout, datacenterID, workerID int64, opts ...Option) (*AtomicSnowflake, error) {
+	n, err := newNode(layout, datacenterID, workerID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &AtomicSnowflake{
+		node:         n,
+		sequenceBits: layout.SequenceBits,
+	}, nil
+}
+
+// NextVal creates and returns a unique snowflake ID, or 0 if it fails.
+// Use Next to find out why an ID could not be generated.
+func (s *AtomicSnowflake) NextVal() ID {
+	r, err := s.Next()
+	if err != nil {
+		return 0
+	}
+	return r
+}
+
+// Next creates and returns a unique snowflake ID, failing with the same
+// errors as Snowflake.Next.
+func (s *AtomicSnowflake) Next() (ID, error) {
+	r, _, err := s.NextContext(context.Background())
+	return r, err
+}
+
+// NextContext creates and returns a unique snowflake ID like Next, along with
+// how long the caller waited for the clock. It sleeps instead of spinning
+// when it has to wait, and fails with ErrContextCanceled if ctx is done first.
+func (s *AtomicSnowflake) NextContext(ctx context.Context) (ID, time.Duration, error) {
+	var waited time.Duration
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, waited, contextError{err}
+		}
+		old := atomic.LoadUint64(&s.state)
+		last := int64(old >> s.sequenceBits)
+		sequence := int64(old) & s.sequenceMask
+
+		t := s.now() - s.epoch
+		if t < 0 {
+			return 0, waited, ErrBeforeEpoch
+		}
+		logical := false
+		if t < last {
+			// The clock moved backwards since the last ID was generated
+			drift := last - t
+			switch s.rollback {
+			case RollbackLogical:
+				t, logical = last, true
+			case RollbackWait:
+				if drift > s.maxDrift {
+					return 0, waited, s.rolledBack(drift)
+				}
+				w, err := s.wait(ctx, last+s.epoch)
+				waited += w
+				if err != nil {
+					return 0, waited, err
+				}
+				continue
+			default:
+				return 0, waited, s.rolledBack(drift)
+			}
+		}
+		if t == last {
+			sequence = (sequence + 1) & s.sequenceMask
+			if sequence == 0 {
+				if !logical {
+					// The sequence is exhausted, wait for the next millisecond and retry
+					w, err := s.wait(ctx, last+1+s.epoch)
+					waited += w
+					if err != nil {
+						return 0, waited, err
+					}
+					continue
+				}
+				t = last + 1
+			}
+		} else {
+			sequence = 0
+		}
+		if t > s.timestampMax {
+			return 0, waited, ErrEpochExhausted
+		}
+		if atomic.CompareAndSwapUint64(&s.state, old, uint64(t)<<s.sequenceBits|uint64(sequence)) {
+			return s.compose(t, sequence), waited, nil
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewAtomicSnowflake(t *testing.T) {
+	_, err := NewAtomicSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+
+	_, err = NewAtomicSnowflake(33, 32)
+	if err == nil {
+		t.Fatalf("no error creating NewAtomicSnowflake")
+	}
+
+	_, err = NewAtomicSnowflakeWithLayout(Layout{}, 0, 0)
+	if err == nil {
+		t.Fatalf("no error creating NewAtomicSnowflake with an empty layout")
+	}
+}
+
+func TestAtomicUnique(t *testing.T) {
+	s, err := NewAtomicSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+
+	var wg sync.WaitGroup
+	var check sync.Map
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var x ID
+			for j := 0; j < 5000; j++ {
+				y := s.NextVal()
+				if y <= x {
+					t.Errorf("y(%d) <= x(%d)", y, x)
+					return
+				}
+				if _, ok := check.LoadOrStore(y, 0); ok {
+					t.Errorf("duplicate ID %d", y)
+					return
+				}
+				x = y
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// The atomic generator must produce exactly the IDs the mutex one does.
+func TestAtomicMatchesSnowflake(t *testing.T) {
+	start := epochTime.Add(time.Hour)
+	mc, ac := NewFakeClock(start), NewFakeClock(start)
+	mc.SetStep(time.Millisecond / 8)
+	ac.SetStep(time.Millisecond / 8)
+
+	m, err := NewSnowflake(3, 7, WithClock(mc))
+	if err != nil {
+		t.Fatalf("error creating NewSnowflake, %s", err)
+	}
+	a, err := NewAtomicSnowflake(3, 7, WithClock(ac))
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+
+	for i := 0; i < 3*int(sequenceMask); i++ {
+		x, y := m.NextVal(), a.NextVal()
+		if x != y {
+			t.Fatalf("%d: mutex %d != atomic %d", i, x, y)
+		}
+	}
+}
+
+func TestAtomicRollback(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Hour))
+	s, err := NewAtomicSnowflake(1, 1, WithClock(c), WithRollbackStrategy(RollbackError))
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+	x, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	c.Advance(-time.Millisecond)
+	if _, err = s.Next(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Fatalf("expected ErrClockMovedBackwards, got %v", err)
+	}
+
+	s, err = NewAtomicSnowflake(1, 1, WithClock(c), WithRollbackStrategy(RollbackLogical))
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+	c.Advance(time.Millisecond)
+	x = s.NextVal()
+	c.Advance(-time.Second)
+	for i := 0; i < 2*int(sequenceMask); i++ {
+		y, err := s.Next()
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if y <= x {
+			t.Fatalf("y(%d) <= x(%d)", y, x)
+		}
+		x = y
+	}
+}
+
+func TestAtomicEpoch(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(-time.Millisecond))
+	s, err := NewAtomicSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+	if _, err = s.Next(); err != ErrBeforeEpoch {
+		t.Fatalf("expected ErrBeforeEpoch, got %v", err)
+	}
+
+	c.Set(epochTime.Add(time.Duration(timestampMax+1) * time.Millisecond))
+	if id := s.NextVal(); id != 0 {
+		t.Fatalf("expected 0 after the epoch is exhausted, got %d", id)
+	}
+	if _, err = s.Next(); err != ErrEpochExhausted {
+		t.Fatalf("expected ErrEpochExhausted, got %v", err)
+	}
+}
+
+func TestAtomicNextContext(t *testing.T) {
+	c := stuckClock{NewFakeClock(epochTime.Add(time.Hour))}
+	s, err := NewAtomicSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+	for i := int64(0); i <= sequenceMask; i++ {
+		if _, err = s.Next(); err != nil {
+			t.Fatalf("error generating ID, %s", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err = s.NextContext(ctx); !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrContextCanceled, got %v", err)
+	}
+
+	c.Advance(time.Millisecond)
+	id, waited, err := s.NextContext(context.Background())
+	if err != nil {
+		t.Fatalf("error generating ID, %s", err)
+	}
+	if waited != 0 || DefaultLayout.Sequence(id) != 0 {
+		t.Fatalf("unexpected wait %s for ID %d", waited, id)
+	}
+}
+
+func BenchmarkAtomicGenerate(b *testing.B) {
+
+	node, _ := NewAtomicSnowflake(1, 1)
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_ = node.NextVal()
+	}
+}
+
+func BenchmarkAtomicGenerateParallel(b *testing.B) {
+
+	node, _ := NewAtomicSnowflake(1, 1)
+
+	b.ReportAllocs()
+	b.SetParallelism(64)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = node.NextVal()
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package snowflake
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by a Snowflake. Every time read made while
+// generating IDs goes through it, so tests can control time precisely.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// After waits for the duration to elapse and then sends the current time
+	// on the returned channel. It is used instead of spinning whenever a
+	// Snowflake has to wait for time to pass.
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the default Clock, backed by time.Now.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// FakeClock is a controllable Clock for tests. It is frozen until moved with
+// Set or Advance, unless a step is configured with SetStep. Waiting on After
+// never blocks: it moves the fake time forward by the requested duration.
+// It is safe for concurrent use.
+type FakeClock struct {
+	mu   sync.Mutex
+	now  time.Time
+	step time.Duration
+}
+
+// NewFakeClock returns a FakeClock frozen at now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current fake time, then moves it forward by the step
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now
+	c.now = c.now.Add(c.step)
+	return now
+}
+
+// After moves the fake time forward by d and returns a channel that already
+// holds the new time
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	c.mu.Unlock()
+	return ch
+}
+
+// Set moves the fake time to t, which may be before the current fake time
+func (c *FakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	c.now = t
+	c.mu.Unlock()
+}
+
+// Advance moves the fake time by d. A negative d moves the clock backwards.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+// SetStep makes every call to Now advance the fake time by d after reading it.
+// A zero step freezes the clock again.
+func (c *FakeClock) SetStep(d time.Duration) {
+	c.mu.Lock()
+	c.step = d
+	c.mu.Unlock()
+}
@@ -0,0 +1,76 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+)
+
+// epochTime is the default epoch as a time.Time
+var epochTime = time.Unix(0, epoch*int64(time.Millisecond))
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2021, 3, 14, 23, 41, 24, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	if !c.Now().Equal(start) || !c.Now().Equal(start) {
+		t.Fatalf("FakeClock is not frozen")
+	}
+
+	c.Advance(time.Second)
+	if now := c.Now(); !now.Equal(start.Add(time.Second)) {
+		t.Fatalf("Now %s != %s", now, start.Add(time.Second))
+	}
+
+	c.Advance(-2 * time.Second)
+	if now := c.Now(); !now.Equal(start.Add(-time.Second)) {
+		t.Fatalf("Now %s != %s", now, start.Add(-time.Second))
+	}
+
+	c.Set(start)
+	c.SetStep(time.Millisecond)
+	if now := c.Now(); !now.Equal(start) {
+		t.Fatalf("Now %s != %s", now, start)
+	}
+	if now := c.Now(); !now.Equal(start.Add(time.Millisecond)) {
+		t.Fatalf("Now %s != %s", now, start.Add(time.Millisecond))
+	}
+}
+
+func TestNextValFakeClock(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(1000 * time.Millisecond))
+	s, err := NewSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+
+	base := ID(1000<<timestampShift | 1<<datacenterIDShift | 1<<workerIDShift)
+	for i := ID(0); i <= ID(sequenceMask); i++ {
+		if id := s.NextVal(); id != base+i {
+			t.Fatalf("id %d != %d", id, base+i)
+		}
+	}
+
+	// The sequence is exhausted, so the next ID has to wait for the clock.
+	if id := s.NextVal(); id != base+1<<timestampShift {
+		t.Fatalf("id %d != %d", id, base+1<<timestampShift)
+	}
+}
+
+func TestNextValEpochExhausted(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Duration(timestampMax) * time.Millisecond))
+	s, err := NewSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+	if id := s.NextVal(); id == 0 {
+		t.Fatalf("unexpected 0 on the last millisecond of the epoch")
+	}
+
+	c.Advance(time.Millisecond)
+	if id := s.NextVal(); id != 0 {
+		t.Fatalf("expected 0 after the epoch is exhausted, got %d", id)
+	}
+	if _, err := s.Next(); err != ErrEpochExhausted {
+		t.Fatalf("expected ErrEpochExhausted, got %v", err)
+	}
+}
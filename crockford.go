@@ -0,0 +1,162 @@
+package snowflake
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+const encodeCrockfordMap = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var decodeCrockfordMap [256]byte
+
+// encodeCrockfordCheckMap holds the Crockford Base32 symbols for the values
+// 0-36 of the mod 37 check symbol
+const encodeCrockfordCheckMap = encodeCrockfordMap + "*~$=U"
+
+var decodeCrockfordCheckMap [256]byte
+
+// ErrInvalidCheckSymbol is returned by ParseCrockfordBase32Check when the
+// check symbol is missing or does not match the value, e.g. after a typo
+var ErrInvalidCheckSymbol = errors.New("invalid crockford base32 check symbol")
+
+// Create maps for decoding Crockford Base32. Decoding is case-insensitive,
+// and I and L are read as 1 and O as 0.
+func init() {
+
+	for i := 0; i < len(decodeCrockfordMap); i++ {
+		decodeCrockfordMap[i] = 0xFF
+	}
+
+	for i := 0; i < len(encodeCrockfordMap); i++ {
+		c := encodeCrockfordMap[i]
+		decodeCrockfordMap[c] = byte(i)
+		decodeCrockfordMap[c|0x20] = byte(i)
+	}
+
+	for _, alias := range []struct {
+		c byte
+		v byte
+	}{{'I', 1}, {'L', 1}, {'O', 0}} {
+		decodeCrockfordMap[alias.c] = alias.v
+		decodeCrockfordMap[alias.c|0x20] = alias.v
+	}
+
+	for i := 0; i < len(decodeCrockfordCheckMap); i++ {
+		decodeCrockfordCheckMap[i] = decodeCrockfordMap[i]
+	}
+
+	for i := len(encodeCrockfordMap); i < len(encodeCrockfordCheckMap); i++ {
+		c := encodeCrockfordCheckMap[i]
+		decodeCrockfordCheckMap[c] = byte(i)
+		decodeCrockfordCheckMap[c|0x20] = byte(i)
+	}
+}
+
+// CrockfordBase32 returns a Crockford Base32 string of the snowflake ID,
+// without leading zeros
+func (sid ID) CrockfordBase32() string {
+
+	u := uint64(sid)
+	if u < 32 {
+		return string(encodeCrockfordMap[u])
+	}
+
+	b := make([]byte, 0, 14)
+	for u >= 32 {
+		b = append(b, encodeCrockfordMap[u%32])
+		u /= 32
+	}
+	b = append(b, encodeCrockfordMap[u])
+
+	for x, y := 0, len(b)-1; x < y; x, y = x+1, y-1 {
+		b[x], b[y] = b[y], b[x]
+	}
+
+	return string(b)
+}
+
+// CrockfordBase32Check returns a Crockford Base32 string of the snowflake ID
+// followed by its mod 37 check symbol
+func (sid ID) CrockfordBase32Check() string {
+	return sid.CrockfordBase32() + string(encodeCrockfordCheckMap[uint64(sid)%37])
+}
+
+// ParseCrockfordBase32 parses a Crockford Base32 string into a snowflake ID.
+// It is case-insensitive, reads I and L as 1 and O as 0, and ignores hyphens.
+func ParseCrockfordBase32(id string) (ID, error) {
+	u, err := parseCrockford(strings.Replace(id, "-", "", -1))
+	if err != nil {
+		return -1, err
+	}
+	return ID(u), nil
+}
+
+// ParseCrockfordBase32Check parses a string produced by CrockfordBase32Check
+// into a snowflake ID like ParseCrockfordBase32, and verifies the trailing
+// check symbol so that mistyped IDs are rejected with ErrInvalidCheckSymbol.
+func ParseCrockfordBase32Check(id string) (ID, error) {
+	id = strings.Replace(id, "-", "", -1)
+	if len(id) == 0 {
+		return -1, ErrEmpty
+	}
+	if len(id) < 2 {
+		return -1, ErrInvalidCheckSymbol
+	}
+	check := decodeCrockfordCheckMap[id[len(id)-1]]
+	if check == 0xFF {
+		return -1, ErrInvalidCheckSymbol
+	}
+	u, err := parseCrockford(id[:len(id)-1])
+	if err != nil {
+		return -1, err
+	}
+	if u%37 != uint64(check) {
+		return -1, ErrInvalidCheckSymbol
+	}
+	return ID(u), nil
+}
+
+// GroupCrockford inserts a hyphen every size characters of a Crockford
+// Base32 string, e.g. to make an ID easier to read over the phone.
+// The parsers ignore the hyphens.
+func GroupCrockford(id string, size int) string {
+	if size <= 0 || len(id) <= size {
+		return id
+	}
+	var b strings.Builder
+	b.Grow(len(id) + (len(id)-1)/size)
+	for i := 0; i < len(id); i += size {
+		if i > 0 {
+			b.WriteByte('-')
+		}
+		end := i + size
+		if end > len(id) {
+			end = len(id)
+		}
+		b.WriteString(id[i:end])
+	}
+	return b.String()
+}
+
+// parseCrockford decodes Crockford Base32 symbols without hyphens
+func parseCrockford(id string) (uint64, error) {
+	if len(id) == 0 {
+		return 0, ErrEmpty
+	}
+
+	var u uint64
+
+	for i := 0; i < len(id); i++ {
+		d := decodeCrockfordMap[id[i]]
+		if d == 0xFF {
+			return 0, ErrInvalidBase32
+		}
+		if u > (math.MaxInt64-uint64(d))/32 {
+			return 0, ErrOverflow
+		}
+		u = u*32 + uint64(d)
+	}
+
+	return u, nil
+}
@@ -0,0 +1,138 @@
+package snowflake
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCrockfordBase32(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		sf := node.NextVal()
+
+		c32 := sf.CrockfordBase32()
+		psf, err := ParseCrockfordBase32(c32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sf != psf {
+			t.Fatal("Parsed does not match String.")
+		}
+
+		c32 = sf.CrockfordBase32Check()
+		psf, err = ParseCrockfordBase32Check(c32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sf != psf {
+			t.Fatal("Parsed does not match String.")
+		}
+	}
+
+	tt := []struct {
+		id    ID
+		c32   string
+		check string
+	}{
+		{0, "0", "00"},
+		{31, "Z", "ZZ"},
+		{32, "10", "10*"},
+		{34, "12", "12$"},
+		{13587, "D8K", "D8K8"},
+		{ID(math.MaxInt64), "7ZZZZZZZZZZZZ", "7ZZZZZZZZZZZZ5"},
+	}
+	for _, tc := range tt {
+		if c32 := tc.id.CrockfordBase32(); c32 != tc.c32 {
+			t.Fatalf("CrockfordBase32(%d) %q != %q", tc.id, c32, tc.c32)
+		}
+		if check := tc.id.CrockfordBase32Check(); check != tc.check {
+			t.Fatalf("CrockfordBase32Check(%d) %q != %q", tc.id, check, tc.check)
+		}
+	}
+}
+
+func TestParseCrockfordBase32Lenient(t *testing.T) {
+	for _, s := range []string{"D8K", "d8k", "D8-K", "-d-8-k-"} {
+		id, err := ParseCrockfordBase32(s)
+		if err != nil || id != 13587 {
+			t.Fatalf("ParseCrockfordBase32(%q) %d, %v", s, id, err)
+		}
+	}
+
+	for _, s := range []string{"1O", "lo", "Io", "i0"} {
+		id, err := ParseCrockfordBase32(s)
+		if err != nil || id != 32 {
+			t.Fatalf("ParseCrockfordBase32(%q) %d, %v", s, id, err)
+		}
+	}
+
+	for _, s := range []string{"", "-", "--"} {
+		if _, err := ParseCrockfordBase32(s); err != ErrEmpty {
+			t.Fatalf("ParseCrockfordBase32(%q) expected ErrEmpty, got %v", s, err)
+		}
+	}
+	for _, s := range []string{"D8U", "D8*", "D 8K"} {
+		if _, err := ParseCrockfordBase32(s); err != ErrInvalidBase32 {
+			t.Fatalf("ParseCrockfordBase32(%q) expected ErrInvalidBase32, got %v", s, err)
+		}
+	}
+	if _, err := ParseCrockfordBase32("80000000000000"); err != ErrOverflow {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+}
+
+func TestParseCrockfordBase32Check(t *testing.T) {
+	for _, s := range []string{"D8K8", "d8k8", "D8-K8", "ZZ", "10*", "11~", "12$", "13="} {
+		if _, err := ParseCrockfordBase32Check(s); err != nil {
+			t.Fatalf("ParseCrockfordBase32Check(%q) %v", s, err)
+		}
+	}
+
+	// 26 % 37 = 26 is T, 36 is U
+	if id, err := ParseCrockfordBase32Check("TT"); err != nil || id != 26 {
+		t.Fatalf("ParseCrockfordBase32Check %d, %v", id, err)
+	}
+	id := ID(36)
+	if c := id.CrockfordBase32Check(); c != "14U" {
+		t.Fatalf("CrockfordBase32Check(36) %q != %q", c, "14U")
+	}
+	if pid, err := ParseCrockfordBase32Check("14u"); err != nil || pid != id {
+		t.Fatalf("ParseCrockfordBase32Check %d, %v", pid, err)
+	}
+
+	// Typos: a changed digit, transposed digits and a missing check symbol.
+	for _, s := range []string{"D9K8", "8DK8", "D8K", "D", "D8K#"} {
+		if _, err := ParseCrockfordBase32Check(s); err != ErrInvalidCheckSymbol {
+			t.Fatalf("ParseCrockfordBase32Check(%q) expected ErrInvalidCheckSymbol, got %v", s, err)
+		}
+	}
+	if _, err := ParseCrockfordBase32Check("D8U8"); err != ErrInvalidBase32 {
+		t.Fatalf("expected ErrInvalidBase32, got %v", err)
+	}
+}
+
+func TestGroupCrockford(t *testing.T) {
+	tt := []struct {
+		in       string
+		size     int
+		expected string
+	}{
+		{"7ZZZZZZZZZZZZ5", 4, "7ZZZ-ZZZZ-ZZZZ-Z5"},
+		{"D8K8", 4, "D8K8"},
+		{"D8K8", 2, "D8-K8"},
+		{"D8K8", 0, "D8K8"},
+	}
+	for _, tc := range tt {
+		g := GroupCrockford(tc.in, tc.size)
+		if g != tc.expected {
+			t.Fatalf("GroupCrockford(%q, %d) %q != %q", tc.in, tc.size, g, tc.expected)
+		}
+		if _, err := ParseCrockfordBase32Check(g); err != nil {
+			t.Fatalf("error parsing %q, %s", g, err)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package snowflake
+
+import (
+	"errors"
+	"math"
+)
+
+// ErrInvalidAlphabet is returned by NewEncoding when the alphabet is too short
+// or too long, contains a non-ASCII or repeated character, or contains both
+// cases of a letter while case folding is enabled
+var ErrInvalidAlphabet = errors.New("invalid snowflake encoding alphabet")
+
+// ErrInvalidPadding is returned by NewEncoding when the padding width is too
+// small to hold every 64-bit value
+var ErrInvalidPadding = errors.New("invalid snowflake encoding padding")
+
+// ErrInvalidCharacter is returned by Encoding.Decode when given a character
+// that is not in the alphabet of a custom encoding
+var ErrInvalidCharacter = errors.New("invalid character in snowflake ID")
+
+// An Encoding writes snowflake IDs as digits taken from an alphabet, most
+// significant digit first, the same way Base32 and Base58 do. The built in
+// encodings are Base32Encoding, Base58Encoding and Base62Encoding.
+//
+// An Encoding is safe for concurrent use by multiple goroutines.
+type Encoding struct {
+	encode   string
+	decode   [256]byte
+	width    int
+	foldCase bool
+	invalid  error
+}
+
+// An EncodingOption configures an Encoding when it is created
+type EncodingOption func(*Encoding)
+
+// WithPadding makes the Encoding write every ID as exactly width characters,
+// padded on the left with the first character of the alphabet, and makes
+// Decode reject values of any other length with ErrInvalidLength. When the
+// alphabet is in ASCII order the padded strings sort in the same order as the
+// IDs. The width must be large enough for every 64-bit value, for example 11
+// for a 62 character alphabet, otherwise NewEncoding returns ErrInvalidPadding.
+func WithPadding(width int) EncodingOption {
+	return func(e *Encoding) {
+		e.width = width
+	}
+}
+
+// WithCaseFolding makes Decode accept letters of the alphabet in either case.
+// Encode still writes them as they appear in the alphabet.
+func WithCaseFolding() EncodingOption {
+	return func(e *Encoding) {
+		e.foldCase = true
+	}
+}
+
+// Base32Encoding is the z-base-32 alphabet used by Base32 and ParseBase32
+var Base32Encoding = mustNewEncoding(encodeBase32Map, ErrInvalidBase32)
+
+// Base58Encoding is the alphabet used by Base58 and ParseBase58
+var Base58Encoding = mustNewEncoding(encodeBase58Map, ErrInvalidBase58)
+
+// Base62Encoding is the alphabet used by Base62 and ParseBase62
+var Base62Encoding = mustNewEncoding(encodeBase62Map, ErrInvalidBase62)
+
+var sortableBase62Encoding = mustNewEncoding(encodeBase62Map, ErrInvalidBase62, WithPadding(sortableBase62Width))
+
+// NewEncoding returns an Encoding that uses the characters of alphabet as its
+// digits, so its length is the base. The alphabet must have between 2 and 128
+// distinct ASCII characters.
+func NewEncoding(alphabet string, opts ...EncodingOption) (*Encoding, error) {
+	return newEncoding(alphabet, ErrInvalidCharacter, opts)
+}
+
+func mustNewEncoding(alphabet string, invalid error, opts ...EncodingOption) *Encoding {
+	e, err := newEncoding(alphabet, invalid, opts)
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func newEncoding(alphabet string, invalid error, opts []EncodingOption) (*Encoding, error) {
+	if len(alphabet) < 2 || len(alphabet) > 128 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	e := &Encoding{encode: alphabet, invalid: invalid}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for i := 0; i < len(e.decode); i++ {
+		e.decode[i] = 0xFF
+	}
+
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 {
+			return nil, ErrInvalidAlphabet
+		}
+		if e.decode[c] != 0xFF {
+			return nil, ErrInvalidAlphabet
+		}
+		e.decode[c] = byte(i)
+		if e.foldCase && isLetter(c) {
+			if e.decode[c^0x20] != 0xFF {
+				return nil, ErrInvalidAlphabet
+			}
+			e.decode[c^0x20] = byte(i)
+		}
+	}
+
+	if e.width != 0 && e.width < len(appendBase(nil, math.MaxUint64, alphabet)) {
+		return nil, ErrInvalidPadding
+	}
+	return e, nil
+}
+
+func isLetter(c byte) bool {
+	c |= 0x20
+	return c >= 'a' && c <= 'z'
+}
+
+// Alphabet returns the characters the Encoding uses as digits
+func (e *Encoding) Alphabet() string {
+	return e.encode
+}
+
+// Encode returns the string of the snowflake ID in the Encoding
+func (e *Encoding) Encode(sid ID) string {
+	var b [64]byte
+	return string(e.Append(b[:0], sid))
+}
+
+// Append appends the string of the snowflake ID in the Encoding to dst and
+// returns the extended buffer
+func (e *Encoding) Append(dst []byte, sid ID) []byte {
+	if e.width == 0 {
+		return appendBase(dst, uint64(sid), e.encode)
+	}
+	n := len(dst)
+	for i := 0; i < e.width; i++ {
+		dst = append(dst, e.encode[0])
+	}
+	u := uint64(sid)
+	base := uint64(len(e.encode))
+	for i := len(dst) - 1; u > 0; i-- {
+		dst[i] = e.encode[u%base]
+		u /= base
+	}
+	return dst[:n+e.width]
+}
+
+// Decode parses a []byte in the Encoding into a snowflake ID
+func (e *Encoding) Decode(b []byte) (ID, error) {
+
+	if len(b) == 0 {
+		return -1, ErrEmpty
+	}
+	if e.width != 0 && len(b) != e.width {
+		return -1, ErrInvalidLength
+	}
+
+	var id int64
+	base := int64(len(e.encode))
+
+	for i := range b {
+		d := e.decode[b[i]]
+		if d == 0xFF {
+			return -1, e.invalid
+		}
+		if id > (math.MaxInt64-int64(d))/base {
+			return -1, ErrOverflow
+		}
+		id = id*base + int64(d)
+	}
+
+	return ID(id), nil
+}
+
+// DecodeString parses a string in the Encoding into a snowflake ID
+func (e *Encoding) DecodeString(s string) (ID, error) {
+	return e.Decode([]byte(s))
+}
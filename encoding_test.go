@@ -0,0 +1,143 @@
+package snowflake
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestNewEncodingErrors(t *testing.T) {
+	tests := []struct {
+		alphabet string
+		opts     []EncodingOption
+		err      error
+	}{
+		{"", nil, ErrInvalidAlphabet},
+		{"a", nil, ErrInvalidAlphabet},
+		{"abca", nil, ErrInvalidAlphabet},
+		{"abcé", nil, ErrInvalidAlphabet},
+		{"abcA", []EncodingOption{WithCaseFolding()}, ErrInvalidAlphabet},
+		{encodeBase62Map, []EncodingOption{WithPadding(10)}, ErrInvalidPadding},
+		{"01", []EncodingOption{WithPadding(63)}, ErrInvalidPadding},
+	}
+	for _, tt := range tests {
+		if _, err := NewEncoding(tt.alphabet, tt.opts...); err != tt.err {
+			t.Errorf("NewEncoding(%q) error %v != %v", tt.alphabet, err, tt.err)
+		}
+	}
+
+	if _, err := NewEncoding("01", WithPadding(64)); err != nil {
+		t.Fatalf("error creating padded base2 encoding, %s", err)
+	}
+}
+
+func TestEncodingBuiltins(t *testing.T) {
+	ids := []ID{0, 1, 57, 58, 13587, 1427970479175499776, math.MaxInt64}
+	for _, id := range ids {
+		if got, want := Base32Encoding.Encode(id), id.Base32(); got != want {
+			t.Errorf("Base32Encoding.Encode(%d) %s != %s", id, got, want)
+		}
+		if got, want := Base58Encoding.Encode(id), id.Base58(); got != want {
+			t.Errorf("Base58Encoding.Encode(%d) %s != %s", id, got, want)
+		}
+		if got, want := Base62Encoding.Encode(id), id.Base62(); got != want {
+			t.Errorf("Base62Encoding.Encode(%d) %s != %s", id, got, want)
+		}
+	}
+
+	if _, err := Base58Encoding.DecodeString("0"); err != ErrInvalidBase58 {
+		t.Fatalf("error %v != %v", err, ErrInvalidBase58)
+	}
+}
+
+func TestEncodingRoundTrip(t *testing.T) {
+	// Crockford alphabet without the check symbols, in reverse order.
+	enc, err := NewEncoding("ZYXWVTSRQPNMKJHGFEDCBA9876543210", WithCaseFolding())
+	if err != nil {
+		t.Fatalf("error creating encoding, %s", err)
+	}
+	if enc.Alphabet() != "ZYXWVTSRQPNMKJHGFEDCBA9876543210" {
+		t.Fatalf("Alphabet %s", enc.Alphabet())
+	}
+
+	for i := 0; i < 1000; i++ {
+		id := ID(rand.Int63())
+		s := enc.Encode(id)
+		pID, err := enc.DecodeString(s)
+		if err != nil {
+			t.Fatalf("error decoding %s, %s", s, err)
+		}
+		if pID != id {
+			t.Fatalf("pID %v != oID %v", pID, id)
+		}
+	}
+
+	if got := enc.Encode(0); got != "Z" {
+		t.Fatalf("Encode(0) %s != Z", got)
+	}
+	for _, s := range []string{"yx", "YX", "yX"} {
+		pID, err := enc.DecodeString(s)
+		if err != nil || pID != 34 {
+			t.Fatalf("DecodeString(%s) = %v, %v", s, pID, err)
+		}
+	}
+	if _, err := enc.DecodeString("I"); err != ErrInvalidCharacter {
+		t.Fatalf("error %v != %v", err, ErrInvalidCharacter)
+	}
+	if _, err := enc.DecodeString(""); err != ErrEmpty {
+		t.Fatalf("error %v != %v", err, ErrEmpty)
+	}
+	if _, err := enc.DecodeString("9ZZZZZZZZZZZZ"); err != ErrOverflow {
+		t.Fatalf("error %v != %v", err, ErrOverflow)
+	}
+}
+
+func TestEncodingPadding(t *testing.T) {
+	enc, err := NewEncoding("0123456789abcdef", WithPadding(16))
+	if err != nil {
+		t.Fatalf("error creating encoding, %s", err)
+	}
+
+	if got := enc.Encode(255); got != "00000000000000ff" {
+		t.Fatalf("Encode(255) %s != 00000000000000ff", got)
+	}
+	if got := string(enc.Append([]byte("x"), 1)); got != "x0000000000000001" {
+		t.Fatalf("Append %s != x0000000000000001", got)
+	}
+	if _, err := enc.DecodeString("ff"); err != ErrInvalidLength {
+		t.Fatalf("error %v != %v", err, ErrInvalidLength)
+	}
+
+	ids := make([]ID, 1000)
+	strs := make([]string, len(ids))
+	for i := range ids {
+		ids[i] = ID(rand.Int63() >> uint(rand.Intn(63)))
+		strs[i] = enc.Encode(ids[i])
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	sort.Strings(strs)
+	for i := range ids {
+		pID, err := enc.DecodeString(strs[i])
+		if err != nil {
+			t.Fatalf("error decoding %s, %s", strs[i], err)
+		}
+		if pID != ids[i] {
+			t.Fatalf("sort order %v != %v", pID, ids[i])
+		}
+	}
+}
+
+func BenchmarkEncodingAppend(b *testing.B) {
+
+	node, _ := NewSnowflake(1, 1)
+	sf := node.NextVal()
+	buf := make([]byte, 0, 64)
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		buf = Base58Encoding.Append(buf[:0], sf)
+	}
+}
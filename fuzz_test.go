@@ -0,0 +1,69 @@
+package snowflake
+
+import (
+	"math"
+	"testing"
+)
+
+// fuzzEncodings lists every encoding with its parser, used to check that any
+// non-negative ID round-trips and that no input makes a parser misbehave.
+var fuzzEncodings = []struct {
+	name   string
+	encode func(ID) string
+	parse  func(string) (ID, error)
+}{
+	{"String", ID.String, ParseString},
+	{"Base2", ID.Base2, ParseBase2},
+	{"Base32", ID.Base32, func(s string) (ID, error) { return ParseBase32([]byte(s)) }},
+	{"Base36", ID.Base36, ParseBase36},
+	{"Base58", ID.Base58, func(s string) (ID, error) { return ParseBase58([]byte(s)) }},
+	{"Base62", ID.Base62, func(s string) (ID, error) { return ParseBase62([]byte(s)) }},
+	{"Base64", ID.Base64, ParseBase64},
+	{"RawBase64", ID.RawBase64, ParseRawBase64},
+	{"RawURLBase64", ID.RawURLBase64, ParseRawURLBase64},
+	{"Bytes", func(sid ID) string { return string(sid.Bytes()) }, func(s string) (ID, error) { return ParseBytes([]byte(s)) }},
+	{"SortableBase32", ID.SortableBase32, ParseSortableBase32},
+	{"SortableBase62", ID.SortableBase62, ParseSortableBase62},
+	{"PaddedString", ID.PaddedString, ParsePaddedString},
+	{"CrockfordBase32", ID.CrockfordBase32, ParseCrockfordBase32},
+	{"CrockfordBase32Check", ID.CrockfordBase32Check, ParseCrockfordBase32Check},
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	for _, seed := range []int64{0, 1, 31, 57, 58, 61, 62, 13587, 1116766490855473152, math.MaxInt64} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, i int64) {
+		if i < 0 {
+			i = -(i + 1)
+		}
+		id := ID(i)
+		for _, enc := range fuzzEncodings {
+			s := enc.encode(id)
+			pid, err := enc.parse(s)
+			if err != nil {
+				t.Fatalf("%s: error parsing %q, %s", enc.name, s, err)
+			}
+			if pid != id {
+				t.Fatalf("%s: pID %d != oID %d", enc.name, pid, id)
+			}
+		}
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{"", "0", "-1", "13587", "3X9", "D8K8", "zzzzzzzzzzzz", "MTM1ODc=", "//////////8"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, enc := range fuzzEncodings {
+			id, err := enc.parse(s)
+			if err != nil {
+				continue
+			}
+			if id < 0 {
+				t.Fatalf("%s: parsing %q gave negative ID %d", enc.name, s, id)
+			}
+		}
+	})
+}
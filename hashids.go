@@ -0,0 +1,251 @@
+package snowflake
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+)
+
+// DefaultHashidsAlphabet is the alphabet NewHashids uses when given none
+const DefaultHashidsAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
+
+const (
+	hashidsSeps           = "cfhistuCFHISTU"
+	hashidsMinAlphabetLen = 16
+	hashidsSepDiv         = 3.5
+	hashidsGuardDiv       = 12
+)
+
+// ErrInvalidHashid is returned by Hashids.Decode when given a code that the
+// Hashids did not produce
+var ErrInvalidHashid = errors.New("invalid hashid")
+
+// ErrInvalidMinLength is returned by NewHashids when given a negative minimum
+// length
+var ErrInvalidMinLength = errors.New("invalid hashid minimum length")
+
+// Hashids encodes one or more snowflake IDs into a short, salted code that
+// looks random, using the Hashids algorithm, so codes are compatible with the
+// Hashids libraries of other languages given the same salt, minimum length
+// and alphabet. The salt only obscures the IDs and is not encryption; use an
+// Obfuscator first to hide them.
+//
+// A Hashids is safe for concurrent use by multiple goroutines.
+type Hashids struct {
+	salt      []byte
+	alphabet  []byte
+	seps      []byte
+	guards    []byte
+	minLength int
+}
+
+// NewHashids returns a Hashids for the salt that pads codes to at least
+// minLength characters. The alphabet must have at least 16 distinct
+// characters and no spaces; an empty alphabet means DefaultHashidsAlphabet.
+func NewHashids(salt string, minLength int, alphabet string) (*Hashids, error) {
+	if minLength < 0 {
+		return nil, ErrInvalidMinLength
+	}
+	if alphabet == "" {
+		alphabet = DefaultHashidsAlphabet
+	}
+	if len(alphabet) < hashidsMinAlphabetLen || strings.IndexByte(alphabet, ' ') >= 0 {
+		return nil, ErrInvalidAlphabet
+	}
+	for i := 0; i < len(alphabet); i++ {
+		if strings.IndexByte(alphabet[:i], alphabet[i]) >= 0 {
+			return nil, ErrInvalidAlphabet
+		}
+	}
+
+	h := &Hashids{salt: []byte(salt), minLength: minLength}
+
+	// The separators are the default ones that are in the alphabet, taken
+	// out of it.
+	for i := 0; i < len(hashidsSeps); i++ {
+		if strings.IndexByte(alphabet, hashidsSeps[i]) >= 0 {
+			h.seps = append(h.seps, hashidsSeps[i])
+		}
+	}
+	for i := 0; i < len(alphabet); i++ {
+		if strings.IndexByte(hashidsSeps, alphabet[i]) < 0 {
+			h.alphabet = append(h.alphabet, alphabet[i])
+		}
+	}
+	hashidsShuffle(h.seps, h.salt)
+
+	if len(h.seps) == 0 || float64(len(h.alphabet))/float64(len(h.seps)) > hashidsSepDiv {
+		n := int(math.Ceil(float64(len(h.alphabet)) / hashidsSepDiv))
+		if n == 1 {
+			n = 2
+		}
+		if n > len(h.seps) {
+			diff := n - len(h.seps)
+			h.seps = append(h.seps, h.alphabet[:diff]...)
+			h.alphabet = h.alphabet[diff:]
+		} else {
+			h.seps = h.seps[:n]
+		}
+	}
+	hashidsShuffle(h.alphabet, h.salt)
+
+	n := int(math.Ceil(float64(len(h.alphabet)) / hashidsGuardDiv))
+	if len(h.alphabet) < 3 {
+		h.guards, h.seps = h.seps[:n], h.seps[n:]
+	} else {
+		h.guards, h.alphabet = h.alphabet[:n], h.alphabet[n:]
+	}
+	return h, nil
+}
+
+// Encode returns the code of one or more snowflake IDs
+func (h *Hashids) Encode(ids ...ID) (string, error) {
+	if len(ids) == 0 {
+		return "", ErrEmpty
+	}
+
+	var sum int64
+	for i, id := range ids {
+		if id < 0 {
+			return "", ErrNegative
+		}
+		sum += int64(id) % int64(i+100)
+	}
+
+	alphabet := append([]byte(nil), h.alphabet...)
+	buffer := make([]byte, 0, 1+len(h.salt)+len(alphabet))
+	lottery := alphabet[sum%int64(len(alphabet))]
+	code := []byte{lottery}
+
+	for i, id := range ids {
+		buffer = append(append(append(buffer[:0], lottery), h.salt...), alphabet...)
+		hashidsShuffle(alphabet, buffer[:len(alphabet)])
+		n := len(code)
+		code = appendBase(code, uint64(id), string(alphabet))
+		if i+1 < len(ids) {
+			r := int64(id) % int64(int(code[n])+i)
+			code = append(code, h.seps[r%int64(len(h.seps))])
+		}
+	}
+
+	if len(code) < h.minLength {
+		g := (sum + int64(code[0])) % int64(len(h.guards))
+		code = append([]byte{h.guards[g]}, code...)
+		if len(code) < h.minLength {
+			g = (sum + int64(code[2])) % int64(len(h.guards))
+			code = append(code, h.guards[g])
+		}
+	}
+
+	half := len(alphabet) / 2
+	for len(code) < h.minLength {
+		hashidsShuffle(alphabet, append(buffer[:0], alphabet...))
+		padded := make([]byte, 0, len(code)+len(alphabet))
+		padded = append(padded, alphabet[half:]...)
+		padded = append(padded, code...)
+		code = append(padded, alphabet[:half]...)
+		if excess := len(code) - h.minLength; excess > 0 {
+			code = code[excess/2 : excess/2+h.minLength]
+		}
+	}
+
+	return string(code), nil
+}
+
+// Decode returns the snowflake IDs encoded in code
+func (h *Hashids) Decode(code string) ([]ID, error) {
+	if len(code) == 0 {
+		return nil, ErrEmpty
+	}
+
+	// Drop the guards added for the minimum length.
+	parts := hashidsSplit(code, h.guards)
+	inner := parts[0]
+	if len(parts) == 2 || len(parts) == 3 {
+		inner = parts[1]
+	}
+	if len(inner) < 2 {
+		return nil, ErrInvalidHashid
+	}
+
+	alphabet := append([]byte(nil), h.alphabet...)
+	buffer := make([]byte, 0, 1+len(h.salt)+len(alphabet))
+	lottery := inner[0]
+	subs := hashidsSplit(inner[1:], h.seps)
+
+	ids := make([]ID, 0, len(subs))
+	for _, sub := range subs {
+		buffer = append(append(append(buffer[:0], lottery), h.salt...), alphabet...)
+		hashidsShuffle(alphabet, buffer[:len(alphabet)])
+		id, err := hashidsUnhash(sub, alphabet)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	// Only codes that encode back to themselves are valid, which rejects
+	// codes that were altered or made with another salt.
+	if again, err := h.Encode(ids...); err != nil || again != code {
+		return nil, ErrInvalidHashid
+	}
+	return ids, nil
+}
+
+// DecodeID returns the snowflake ID encoded in a code holding a single ID
+func (h *Hashids) DecodeID(code string) (ID, error) {
+	ids, err := h.Decode(code)
+	if err != nil {
+		return -1, err
+	}
+	if len(ids) != 1 {
+		return -1, ErrInvalidHashid
+	}
+	return ids[0], nil
+}
+
+// hashidsShuffle permutes alphabet in place, driven by salt
+func hashidsShuffle(alphabet, salt []byte) {
+	if len(salt) == 0 {
+		return
+	}
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		c := int(salt[v])
+		p += c
+		j := (c + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
+
+// hashidsUnhash is the inverse of appendBase for the shuffled alphabet
+func hashidsUnhash(s string, alphabet []byte) (ID, error) {
+	var id int64
+	base := int64(len(alphabet))
+	for i := 0; i < len(s); i++ {
+		d := int64(bytes.IndexByte(alphabet, s[i]))
+		if d < 0 {
+			return -1, ErrInvalidHashid
+		}
+		if id > (math.MaxInt64-d)/base {
+			return -1, ErrOverflow
+		}
+		id = id*base + d
+	}
+	return ID(id), nil
+}
+
+// hashidsSplit splits s around each of the characters in seps, keeping empty
+// parts
+func hashidsSplit(s string, seps []byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if bytes.IndexByte(seps, s[i]) >= 0 {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
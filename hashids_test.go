@@ -0,0 +1,152 @@
+package snowflake
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestHashidsVectors(t *testing.T) {
+	// Codes produced by the reference Hashids implementations.
+	tests := []struct {
+		salt      string
+		minLength int
+		ids       []ID
+		code      string
+	}{
+		{"this is my salt", 0, []ID{12345}, "NkK9"},
+		{"this is my salt", 0, []ID{683, 94108, 123, 5}, "aBMswoO2UB3Sj"},
+		{"this is my salt", 0, []ID{1, 2, 3}, "laHquq"},
+		{"this is my salt", 8, []ID{1}, "gB0NV05e"},
+		{"", 0, []ID{1, 2, 3}, "o2fXhV"},
+	}
+	for _, tt := range tests {
+		h, err := NewHashids(tt.salt, tt.minLength, "")
+		if err != nil {
+			t.Fatalf("error creating NewHashids, %s", err)
+		}
+		code, err := h.Encode(tt.ids...)
+		if err != nil {
+			t.Fatalf("error encoding %v, %s", tt.ids, err)
+		}
+		if code != tt.code {
+			t.Errorf("Encode(%v) %s != %s", tt.ids, code, tt.code)
+		}
+		ids, err := h.Decode(tt.code)
+		if err != nil {
+			t.Fatalf("error decoding %s, %s", tt.code, err)
+		}
+		if !reflect.DeepEqual(ids, tt.ids) {
+			t.Errorf("Decode(%s) %v != %v", tt.code, ids, tt.ids)
+		}
+	}
+}
+
+func TestHashidsRoundTrip(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	for _, minLength := range []int{0, 10, 30} {
+		for _, alphabet := range []string{"", "0123456789abcdef", encodeBase58Map} {
+			h, err := NewHashids("salt", minLength, alphabet)
+			if err != nil {
+				t.Fatalf("error creating NewHashids(%q), %s", alphabet, err)
+			}
+			for i := 0; i < 100; i++ {
+				ids := []ID{node.NextVal(), ID(rand.Int63()), 0, math.MaxInt64}[:1+i%4]
+				code, err := h.Encode(ids...)
+				if err != nil {
+					t.Fatalf("error encoding %v, %s", ids, err)
+				}
+				if len(code) < minLength {
+					t.Fatalf("code %s shorter than %d", code, minLength)
+				}
+				got, err := h.Decode(code)
+				if err != nil {
+					t.Fatalf("error decoding %s, %s", code, err)
+				}
+				if !reflect.DeepEqual(got, ids) {
+					t.Fatalf("Decode(%s) %v != %v", code, got, ids)
+				}
+			}
+		}
+	}
+}
+
+func TestHashidsDecodeID(t *testing.T) {
+	h, err := NewHashids("this is my salt", 0, "")
+	if err != nil {
+		t.Fatalf("error creating NewHashids, %s", err)
+	}
+
+	id, err := h.DecodeID("NkK9")
+	if err != nil || id != 12345 {
+		t.Fatalf("DecodeID(NkK9) = %v, %v", id, err)
+	}
+	if _, err := h.DecodeID("laHquq"); err != ErrInvalidHashid {
+		t.Fatalf("error %v != %v", err, ErrInvalidHashid)
+	}
+}
+
+func TestHashidsErrors(t *testing.T) {
+	if _, err := NewHashids("", -1, ""); err != ErrInvalidMinLength {
+		t.Fatalf("error %v != %v", err, ErrInvalidMinLength)
+	}
+	for _, alphabet := range []string{"abcdefghijklmno", "abcdefghijklmnop ", "abcdefghijklmnopa"} {
+		if _, err := NewHashids("", 0, alphabet); err != ErrInvalidAlphabet {
+			t.Fatalf("NewHashids(%q) error %v != %v", alphabet, err, ErrInvalidAlphabet)
+		}
+	}
+
+	h, err := NewHashids("this is my salt", 0, "")
+	if err != nil {
+		t.Fatalf("error creating NewHashids, %s", err)
+	}
+	if _, err := h.Encode(); err != ErrEmpty {
+		t.Fatalf("error %v != %v", err, ErrEmpty)
+	}
+	if _, err := h.Encode(1, -1); err != ErrNegative {
+		t.Fatalf("error %v != %v", err, ErrNegative)
+	}
+
+	other, _ := NewHashids("another salt", 0, "")
+	for _, code := range []string{"", "N", "NkK8", "NkK9!", "aBMswoO2UB3S"} {
+		if _, err := h.Decode(code); err == nil {
+			t.Errorf("Decode(%q) succeeded", code)
+		}
+	}
+	if _, err := other.Decode("NkK9"); err == nil {
+		t.Errorf("Decode with another salt succeeded")
+	}
+}
+
+func BenchmarkHashidsEncode(b *testing.B) {
+
+	h, _ := NewHashids("this is my salt", 8, "")
+	node, _ := NewSnowflake(1, 1)
+	sf := node.NextVal()
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = h.Encode(sf)
+	}
+}
+
+func BenchmarkHashidsDecode(b *testing.B) {
+
+	h, _ := NewHashids("this is my salt", 8, "")
+	node, _ := NewSnowflake(1, 1)
+	code, _ := h.Encode(node.NextVal())
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		_, _ = h.Decode(code)
+	}
+}
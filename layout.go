@@ -0,0 +1,146 @@
+package snowflake
+
+import (
+	"fmt"
+	"time"
+)
+
+// Layout describes how the 63 usable bits of an ID are split between the
+// timestamp, the data center id, the machine id and the sequence.
+// The sign bit is always reserved, so the four fields must sum to 63.
+//
+// Epoch is the start time the timestamp field counts from. A zero Epoch
+// means the package default of 2020-01-01 00:00:00 (UTC+8).
+type Layout struct {
+	TimestampBits    uint      // Number of digits occupied by timestamp
+	DatacenterIDBits uint      // Number of places occupied by id in the data center
+	WorkerIDBits     uint      // Number of bits occupied by machine id
+	SequenceBits     uint      // The number of digits occupied by the sequence
+	Epoch            time.Time // Start time of the timestamp, zero means the default epoch
+}
+
+// DefaultLayout is the classic 41/5/5/12 split used by NewSnowflake.
+var DefaultLayout = Layout{
+	TimestampBits:    timestampBits,
+	DatacenterIDBits: datacenterIDBits,
+	WorkerIDBits:     workerIDBits,
+	SequenceBits:     sequenceBits,
+}
+
+// Validate reports whether the layout can be used to generate IDs.
+func (l Layout) Validate() error {
+	if l.TimestampBits == 0 {
+		return fmt.Errorf("layout must reserve at least one timestamp bit")
+	}
+	if l.SequenceBits == 0 {
+		return fmt.Errorf("layout must reserve at least one sequence bit")
+	}
+	for _, bits := range []uint{l.TimestampBits, l.DatacenterIDBits, l.WorkerIDBits, l.SequenceBits} {
+		if bits > 63 {
+			return fmt.Errorf("layout field of %d bits exceeds 63", bits)
+		}
+	}
+	if n := l.TimestampBits + l.DatacenterIDBits + l.WorkerIDBits + l.SequenceBits; n != 63 {
+		return fmt.Errorf("layout bits must sum to 63, got %d", n)
+	}
+	if !l.Epoch.IsZero() && (l.Epoch.Year() < 1678 || l.Epoch.Year() > 2261) {
+		return fmt.Errorf("layout epoch %s is out of range", l.Epoch)
+	}
+	return nil
+}
+
+// WithEpoch returns a copy of the layout whose timestamp counts from epoch
+func (l Layout) WithEpoch(epoch time.Time) Layout {
+	l.Epoch = epoch
+	return l
+}
+
+// EpochMillis returns the epoch of the layout as a unix timestamp in milliseconds
+func (l Layout) EpochMillis() int64 {
+	if l.Epoch.IsZero() {
+		return epoch
+	}
+	return l.Epoch.UnixNano() / 1000000
+}
+
+// TimestampMax returns the timestamp maximum of the layout
+func (l Layout) TimestampMax() int64 {
+	return int64(-1 ^ (-1 << l.TimestampBits))
+}
+
+// DatacenterIDMax returns the maximum data center id supported by the layout
+func (l Layout) DatacenterIDMax() int64 {
+	return int64(-1 ^ (-1 << l.DatacenterIDBits))
+}
+
+// WorkerIDMax returns the maximum machine id supported by the layout
+func (l Layout) WorkerIDMax() int64 {
+	return int64(-1 ^ (-1 << l.WorkerIDBits))
+}
+
+// SequenceMask returns the maximum sequence id supported by the layout
+func (l Layout) SequenceMask() int64 {
+	return int64(-1 ^ (-1 << l.SequenceBits))
+}
+
+// workerIDShift returns the number of left shifts of machine id
+func (l Layout) workerIDShift() uint {
+	return l.SequenceBits
+}
+
+// datacenterIDShift returns the number of left shifts of data center id
+func (l Layout) datacenterIDShift() uint {
+	return l.SequenceBits + l.WorkerIDBits
+}
+
+// timestampShift returns the number of left shifts of the timestamp
+func (l Layout) timestampShift() uint {
+	return l.SequenceBits + l.WorkerIDBits + l.DatacenterIDBits
+}
+
+// Timestamp returns the raw timestamp field of the snowflake ID, in
+// milliseconds since the epoch.
+func (l Layout) Timestamp(sid ID) int64 {
+	return (int64(sid) >> l.timestampShift()) & l.TimestampMax()
+}
+
+// DeviceID returns the data center ID and machine ID fields of the snowflake ID
+func (l Layout) DeviceID(sid ID) (datacenterID, workerID int64) {
+	datacenterID = (int64(sid) >> l.datacenterIDShift()) & l.DatacenterIDMax()
+	workerID = (int64(sid) >> l.workerIDShift()) & l.WorkerIDMax()
+	return
+}
+
+// Sequence returns the sequence field of the snowflake ID
+func (l Layout) Sequence(sid ID) int64 {
+	return int64(sid) & l.SequenceMask()
+}
+
+// Time returns an int64 unix timestamp in milliseconds of the snowflake ID time
+func (l Layout) Time(sid ID) int64 {
+	return l.Timestamp(sid) + l.EpochMillis()
+}
+
+// GenTime returns the creation time of the snowflake ID in UTC
+// (precision: milliseconds)
+func (l Layout) GenTime(sid ID) time.Time {
+	// Split the milliseconds rather than multiplying them into nanoseconds,
+	// which overflows after 2262.
+	ms := l.Time(sid)
+	return time.Unix(ms/1000, ms%1000*int64(time.Millisecond)).UTC()
+}
+
+// Format returns the creation time of the snowflake ID formatted with the
+// time layout in the location loc. A nil loc formats in UTC.
+func (l Layout) Format(sid ID, layout string, loc *time.Location) string {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return l.GenTime(sid).In(loc).Format(layout)
+}
+
+// TimestampStatus returns the percentage of the timestamp field already used
+// by the current time: range (0.0-1.0)
+func (l Layout) TimestampStatus() float64 {
+	return float64(time.Now().UnixNano()/1000000-l.EpochMillis()) / float64(l.TimestampMax())
+}
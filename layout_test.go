@@ -0,0 +1,187 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLayoutValidate(t *testing.T) {
+	tt := []struct {
+		layout Layout
+		valid  bool
+	}{
+		{DefaultLayout, true},
+		{Layout{TimestampBits: 41, DatacenterIDBits: 1, WorkerIDBits: 9, SequenceBits: 12}, true},
+		{Layout{TimestampBits: 41, DatacenterIDBits: 0, WorkerIDBits: 10, SequenceBits: 12}, true},
+		{Layout{TimestampBits: 41, DatacenterIDBits: 5, WorkerIDBits: 5, SequenceBits: 13}, false},
+		{Layout{TimestampBits: 0, DatacenterIDBits: 20, WorkerIDBits: 31, SequenceBits: 12}, false},
+		{Layout{TimestampBits: 51, DatacenterIDBits: 6, WorkerIDBits: 6, SequenceBits: 0}, false},
+		{Layout{}, false},
+		{Layout{TimestampBits: ^uint(0), DatacenterIDBits: 63, SequenceBits: 1}, false},
+		{Layout{TimestampBits: 1, WorkerIDBits: ^uint(0) - 64, SequenceBits: 127}, false},
+	}
+
+	for _, tc := range tt {
+		err := tc.layout.Validate()
+		if tc.valid && err != nil {
+			t.Fatalf("layout %+v: unexpected error %s", tc.layout, err)
+		}
+		if !tc.valid && err == nil {
+			t.Fatalf("layout %+v: expected an error", tc.layout)
+		}
+	}
+}
+
+func TestDefaultLayoutMax(t *testing.T) {
+	if DefaultLayout.TimestampMax() != GetTimestampMax() {
+		t.Fatalf("TimestampMax %d != %d", DefaultLayout.TimestampMax(), GetTimestampMax())
+	}
+	if DefaultLayout.DatacenterIDMax() != GetDatacenterIDMax() {
+		t.Fatalf("DatacenterIDMax %d != %d", DefaultLayout.DatacenterIDMax(), GetDatacenterIDMax())
+	}
+	if DefaultLayout.WorkerIDMax() != GetWorkerIDMax() {
+		t.Fatalf("WorkerIDMax %d != %d", DefaultLayout.WorkerIDMax(), GetWorkerIDMax())
+	}
+	if DefaultLayout.SequenceMask() != GetSequenceMask() {
+		t.Fatalf("SequenceMask %d != %d", DefaultLayout.SequenceMask(), GetSequenceMask())
+	}
+}
+
+func TestNewSnowflakeWithLayout(t *testing.T) {
+	layout := Layout{TimestampBits: 41, DatacenterIDBits: 1, WorkerIDBits: 9, SequenceBits: 12}
+
+	_, err := NewSnowflakeWithLayout(layout, 2, 0)
+	if err == nil {
+		t.Fatalf("no error creating snowflake with datacenter id 2")
+	}
+
+	_, err = NewSnowflakeWithLayout(Layout{}, 0, 0)
+	if err == nil {
+		t.Fatalf("no error creating snowflake with an empty layout")
+	}
+
+	s, err := NewSnowflakeWithLayout(layout, 1, 511)
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+	if s.Layout() != layout {
+		t.Fatalf("Layout %+v != %+v", s.Layout(), layout)
+	}
+
+	for i := 0; i < 100; i++ {
+		id := s.NextVal()
+		datacenterID, workerID := layout.DeviceID(id)
+		if datacenterID != 1 || workerID != 511 {
+			t.Fatalf("DeviceID (%d, %d) != (1, 511)", datacenterID, workerID)
+		}
+		if ts := layout.Timestamp(id); ts != GetTimestamp(id) {
+			t.Fatalf("Timestamp %d != %d", ts, GetTimestamp(id))
+		}
+		if layout.Time(id) != id.Time() {
+			t.Fatalf("Time %d != %d", layout.Time(id), id.Time())
+		}
+	}
+}
+
+func TestLayoutSequence(t *testing.T) {
+	layout := Layout{TimestampBits: 43, DatacenterIDBits: 2, WorkerIDBits: 8, SequenceBits: 10}
+	s, err := NewSnowflakeWithLayout(layout, 3, 200)
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+
+	var x, y ID
+	for i := 0; i < 5000; i++ {
+		y = s.NextVal()
+		if y <= x {
+			t.Fatalf("y(%d) <= x(%d)", y, x)
+		}
+		if seq := layout.Sequence(y); seq < 0 || seq > layout.SequenceMask() {
+			t.Fatalf("sequence %d out of range", seq)
+		}
+		x = y
+	}
+}
+
+func TestLayoutEpoch(t *testing.T) {
+	start := time.Now().Add(-time.Hour)
+	layout := DefaultLayout.WithEpoch(start)
+	if layout.EpochMillis() != start.UnixNano()/1000000 {
+		t.Fatalf("EpochMillis %d != %d", layout.EpochMillis(), start.UnixNano()/1000000)
+	}
+	if DefaultLayout.EpochMillis() != epoch {
+		t.Fatalf("default EpochMillis %d != %d", DefaultLayout.EpochMillis(), epoch)
+	}
+
+	s, err := NewSnowflakeWithLayout(layout, 1, 1)
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+
+	before := time.Now().UnixNano() / 1000000
+	id := s.NextVal()
+	after := time.Now().UnixNano() / 1000000
+
+	if ts := layout.Timestamp(id); ts < time.Hour.Milliseconds() {
+		t.Fatalf("Timestamp %d is less than an hour after the epoch", ts)
+	}
+	if ms := layout.Time(id); ms < before || ms > after {
+		t.Fatalf("Time %d not in [%d, %d]", ms, before, after)
+	}
+	if id.Time() == layout.Time(id) {
+		t.Fatalf("default epoch decoded the same time as the custom epoch")
+	}
+	if status := layout.TimestampStatus(); status <= 0 || status >= GetTimestampStatus() {
+		t.Fatalf("TimestampStatus %f not in (0, %f)", status, GetTimestampStatus())
+	}
+}
+
+func TestLayoutFutureEpoch(t *testing.T) {
+	s, err := NewSnowflakeWithLayout(DefaultLayout.WithEpoch(time.Now().Add(time.Hour)), 1, 1)
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+	if id := s.NextVal(); id != 0 {
+		t.Fatalf("expected 0 generating before the epoch, got %d", id)
+	}
+	if _, err := s.Next(); err != ErrBeforeEpoch {
+		t.Fatalf("expected ErrBeforeEpoch, got %v", err)
+	}
+}
+
+func TestLayoutGenTime(t *testing.T) {
+	layout := DefaultLayout.WithEpoch(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	created := time.Date(2024, 6, 1, 12, 0, 0, 5000000, time.UTC)
+	s, err := NewSnowflakeWithLayout(layout, 1, 1, WithClock(NewFakeClock(created)))
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+	id := s.NextVal()
+
+	if gt := layout.GenTime(id); !gt.Equal(created) {
+		t.Fatalf("GenTime %s != %s", gt, created)
+	}
+	if f := layout.Format(id, time.RFC3339Nano, time.UTC); f != "2024-06-01T12:00:00.005Z" {
+		t.Fatalf("Format %q != %q", f, "2024-06-01T12:00:00.005Z")
+	}
+}
+
+func TestLayoutFormatAfter2262(t *testing.T) {
+	layout := DefaultLayout.WithEpoch(time.Date(2250, 1, 1, 0, 0, 0, 0, time.UTC))
+	created := time.Date(2300, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	id, err := layout.Compose(Parts{Time: created, DatacenterID: 1, WorkerID: 1})
+	if err != nil {
+		t.Fatalf("error composing, %s", err)
+	}
+
+	if gt := layout.GenTime(id); !gt.Equal(created) {
+		t.Fatalf("GenTime %s != %s", gt, created)
+	}
+	if f := layout.Format(id, time.RFC3339Nano, nil); f != "2300-01-02T03:04:05.006Z" {
+		t.Fatalf("Format %q != %q", f, "2300-01-02T03:04:05.006Z")
+	}
+	loc := time.FixedZone("UTC+8", 8*60*60)
+	if f := layout.Format(id, "2006-01-02 15:04:05.000 -0700", loc); f != "2300-01-02 11:04:05.006 +0800" {
+		t.Fatalf("Format %q != %q", f, "2300-01-02 11:04:05.006 +0800")
+	}
+}
@@ -0,0 +1,106 @@
+package snowflake
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+)
+
+// obfuscatorRounds is the number of Feistel rounds, one per 64-bit word of
+// a SHA-512 sum
+const obfuscatorRounds = sha512.Size / 8
+
+// An Obfuscator scrambles snowflake IDs with a keyed permutation of the
+// non-negative IDs, so IDs shown to the public no longer reveal when they
+// were created or by which worker, while the server can still recover them.
+//
+// The permutation is a balanced Feistel network over 64 bits whose round keys
+// come from the SHA-512 sum of the key. Results outside the non-negative
+// range are encrypted again until they fall back in it (cycle walking), so
+// Obfuscate maps every non-negative ID to a distinct non-negative ID. Negative
+// IDs are returned unchanged.
+//
+// It hides the structure of IDs from casual observers but is not a vetted
+// cipher; do not rely on it to protect secrets. An Obfuscator is safe for
+// concurrent use by multiple goroutines.
+type Obfuscator struct {
+	keys [obfuscatorRounds]uint64
+}
+
+// NewObfuscator returns an Obfuscator for key. Use a long, random, secret key
+// and keep it stable: IDs obfuscated with one key can only be revealed with
+// the same key.
+func NewObfuscator(key []byte) *Obfuscator {
+	sum := sha512.Sum512(key)
+	o := &Obfuscator{}
+	for i := range o.keys {
+		o.keys[i] = binary.BigEndian.Uint64(sum[i*8:])
+	}
+	return o
+}
+
+// Obfuscate returns the scrambled form of the snowflake ID
+func (o *Obfuscator) Obfuscate(sid ID) ID {
+	if sid < 0 {
+		return sid
+	}
+	u := uint64(sid)
+	for {
+		u = o.encrypt(u)
+		if int64(u) >= 0 {
+			return ID(u)
+		}
+	}
+}
+
+// Reveal returns the snowflake ID that Obfuscate scrambled into sid
+func (o *Obfuscator) Reveal(sid ID) ID {
+	if sid < 0 {
+		return sid
+	}
+	u := uint64(sid)
+	for {
+		u = o.decrypt(u)
+		if int64(u) >= 0 {
+			return ID(u)
+		}
+	}
+}
+
+func (o *Obfuscator) encrypt(u uint64) uint64 {
+	l, r := uint32(u>>32), uint32(u)
+	for _, k := range o.keys {
+		l, r = r, l^feistel(r, k)
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+func (o *Obfuscator) decrypt(u uint64) uint64 {
+	l, r := uint32(u>>32), uint32(u)
+	for i := len(o.keys) - 1; i >= 0; i-- {
+		l, r = r^feistel(l, o.keys[i]), l
+	}
+	return uint64(l)<<32 | uint64(r)
+}
+
+// feistel is the round function, the splitmix64 finalizer of the half block
+// mixed with the round key
+func feistel(r uint32, k uint64) uint32 {
+	x := uint64(r) ^ k
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return uint32(x >> 32)
+}
+
+// Obfuscate scrambles the snowflake ID with key; see Obfuscator. Create an
+// Obfuscator once when scrambling many IDs with the same key.
+func Obfuscate(sid ID, key []byte) ID {
+	return NewObfuscator(key).Obfuscate(sid)
+}
+
+// Reveal returns the snowflake ID that Obfuscate scrambled into sid with key
+func Reveal(sid ID, key []byte) ID {
+	return NewObfuscator(key).Reveal(sid)
+}
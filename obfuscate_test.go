@@ -0,0 +1,114 @@
+package snowflake
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestObfuscateRoundTrip(t *testing.T) {
+	o := NewObfuscator([]byte("a secret key"))
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	ids := []ID{0, 1, math.MaxInt64, math.MaxInt64 - 1}
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, ID(rand.Int63()), node.NextVal())
+	}
+
+	for _, id := range ids {
+		oID := o.Obfuscate(id)
+		if oID < 0 {
+			t.Fatalf("Obfuscate(%d) = %d, want a non-negative ID", id, oID)
+		}
+		if pID := o.Reveal(oID); pID != id {
+			t.Fatalf("pID %v != oID %v", pID, id)
+		}
+	}
+}
+
+func TestObfuscateScrambles(t *testing.T) {
+	o := NewObfuscator([]byte("a secret key"))
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	// Consecutive IDs must not map to consecutive or repeated IDs.
+	seen := make(map[ID]bool)
+	prev := o.Obfuscate(node.NextVal())
+	increasing := 0
+	for i := 0; i < 1000; i++ {
+		oID := o.Obfuscate(node.NextVal())
+		if seen[oID] {
+			t.Fatalf("duplicate obfuscated ID %d", oID)
+		}
+		seen[oID] = true
+		if oID > prev {
+			increasing++
+		}
+		prev = oID
+	}
+	if increasing < 400 || increasing > 600 {
+		t.Fatalf("obfuscated IDs increased %d times out of 1000", increasing)
+	}
+
+	other := NewObfuscator([]byte("another key"))
+	if o.Obfuscate(42) == other.Obfuscate(42) {
+		t.Fatalf("different keys gave the same obfuscated ID")
+	}
+}
+
+func TestObfuscateNegative(t *testing.T) {
+	o := NewObfuscator([]byte("a secret key"))
+	for _, id := range []ID{-1, math.MinInt64} {
+		if got := o.Obfuscate(id); got != id {
+			t.Fatalf("Obfuscate(%d) = %d", id, got)
+		}
+		if got := o.Reveal(id); got != id {
+			t.Fatalf("Reveal(%d) = %d", id, got)
+		}
+	}
+}
+
+func TestObfuscateFuncs(t *testing.T) {
+	key := []byte("a secret key")
+	id := ID(1427970479175499776)
+	oID := Obfuscate(id, key)
+	if oID != NewObfuscator(key).Obfuscate(id) {
+		t.Fatalf("Obfuscate %d != Obfuscator.Obfuscate", oID)
+	}
+	if pID := Reveal(oID, key); pID != id {
+		t.Fatalf("pID %v != oID %v", pID, id)
+	}
+}
+
+func BenchmarkObfuscate(b *testing.B) {
+
+	o := NewObfuscator([]byte("a secret key"))
+	node, _ := NewSnowflake(1, 1)
+	sf := node.NextVal()
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		o.Obfuscate(sf)
+	}
+}
+
+func BenchmarkReveal(b *testing.B) {
+
+	o := NewObfuscator([]byte("a secret key"))
+	node, _ := NewSnowflake(1, 1)
+	sf := o.Obfuscate(node.NextVal())
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		o.Reveal(sf)
+	}
+}
@@ -0,0 +1,52 @@
+package snowflake
+
+import "time"
+
+// An Option configures a Snowflake when it is created
+type Option func(*options)
+
+type options struct {
+	clock    Clock
+	rollback RollbackStrategy
+	maxDrift time.Duration
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		clock:    SystemClock,
+		rollback: RollbackWait,
+		maxDrift: DefaultMaxClockDrift,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithClock makes the Snowflake read time from c instead of the system clock
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		if c != nil {
+			o.clock = c
+		}
+	}
+}
+
+// WithRollbackStrategy sets what the Snowflake does when the clock moves
+// backwards. The default is RollbackWait.
+func WithRollbackStrategy(strategy RollbackStrategy) Option {
+	return func(o *options) {
+		o.rollback = strategy
+	}
+}
+
+// WithMaxClockDrift sets how far the clock may move backwards before a
+// Snowflake using RollbackWait fails instead of waiting.
+// The default is DefaultMaxClockDrift.
+func WithMaxClockDrift(d time.Duration) Option {
+	return func(o *options) {
+		if d >= 0 {
+			o.maxDrift = d
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package snowflake
+
+import (
+	"fmt"
+	"time"
+)
+
+// Parts holds the fields a snowflake ID is made of
+type Parts struct {
+	Time         time.Time // Creation time of the ID in UTC (precision: milliseconds)
+	Timestamp    int64     // Raw timestamp field, in milliseconds since the epoch
+	DatacenterID int64     // Data center id
+	WorkerID     int64     // Machine id
+	Sequence     int64     // Sequence within the millisecond
+}
+
+// Decompose splits the snowflake ID into its parts according to the layout
+func (l Layout) Decompose(sid ID) Parts {
+	datacenterID, workerID := l.DeviceID(sid)
+	return Parts{
+		Time:         l.GenTime(sid),
+		Timestamp:    l.Timestamp(sid),
+		DatacenterID: datacenterID,
+		WorkerID:     workerID,
+		Sequence:     l.Sequence(sid),
+	}
+}
+
+// Compose builds a snowflake ID from its parts according to the layout.
+// The timestamp is taken from Time when it is set, otherwise from Timestamp;
+// if both are set they must agree. Every part must fit in its bits, and a
+// Time the layout cannot hold gives ErrTimeOutOfRange.
+func (l Layout) Compose(p Parts) (ID, error) {
+	if err := l.Validate(); err != nil {
+		return 0, err
+	}
+	ts := p.Timestamp
+	if !p.Time.IsZero() {
+		t, err := l.timestamp(p.Time)
+		if err != nil {
+			return 0, err
+		}
+		ts = t
+		if p.Timestamp != 0 && p.Timestamp != ts {
+			return 0, fmt.Errorf("time %s does not match timestamp %d", p.Time, p.Timestamp)
+		}
+	}
+	if ts < 0 || ts > l.TimestampMax() {
+		return 0, fmt.Errorf("timestamp must be between 0 and %d", l.TimestampMax())
+	}
+	if p.DatacenterID < 0 || p.DatacenterID > l.DatacenterIDMax() {
+		return 0, fmt.Errorf("datacenterid must be between 0 and %d", l.DatacenterIDMax())
+	}
+	if p.WorkerID < 0 || p.WorkerID > l.WorkerIDMax() {
+		return 0, fmt.Errorf("workerid must be between 0 and %d", l.WorkerIDMax())
+	}
+	if p.Sequence < 0 || p.Sequence > l.SequenceMask() {
+		return 0, fmt.Errorf("sequence must be between 0 and %d", l.SequenceMask())
+	}
+	return ID(ts<<l.timestampShift() | p.DatacenterID<<l.datacenterIDShift() | p.WorkerID<<l.workerIDShift() | p.Sequence), nil
+}
+
+// Decompose splits the snowflake ID into its parts using the layout and epoch
+// of the snowflake node
+func (n *node) Decompose(sid ID) Parts {
+	return n.layout.Decompose(sid)
+}
+
+// Compose builds a snowflake ID from its parts using the layout and epoch of
+// the snowflake node
+func (n *node) Compose(p Parts) (ID, error) {
+	return n.layout.Compose(p)
+}
+
+// Parts splits the snowflake ID into its parts using the default layout
+func (sid ID) Parts() Parts {
+	return DefaultLayout.Decompose(sid)
+}
+
+// Compose builds a snowflake ID from its parts using the default layout
+func Compose(p Parts) (ID, error) {
+	return DefaultLayout.Compose(p)
+}
@@ -0,0 +1,130 @@
+package snowflake
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParts(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(1234 * time.Millisecond))
+	s, err := NewSnowflake(28, 11, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+	s.NextVal()
+	id := s.NextVal()
+
+	p := id.Parts()
+	expected := Parts{
+		Time:         epochTime.Add(1234 * time.Millisecond).UTC(),
+		Timestamp:    1234,
+		DatacenterID: 28,
+		WorkerID:     11,
+		Sequence:     1,
+	}
+	if p != expected {
+		t.Fatalf("Parts %+v != %+v", p, expected)
+	}
+	if s.Decompose(id) != p {
+		t.Fatalf("Decompose %+v != %+v", s.Decompose(id), p)
+	}
+
+	cid, err := Compose(p)
+	if err != nil {
+		t.Fatalf("error composing, %s", err)
+	}
+	if cid != id {
+		t.Fatalf("Compose %d != %d", cid, id)
+	}
+}
+
+func TestPartsLayout(t *testing.T) {
+	layout := Layout{
+		TimestampBits:    42,
+		DatacenterIDBits: 1,
+		WorkerIDBits:     10,
+		SequenceBits:     10,
+		Epoch:            time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	c := NewFakeClock(layout.Epoch.Add(time.Minute))
+	s, err := NewAtomicSnowflakeWithLayout(layout, 1, 1000, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+	id := s.NextVal()
+
+	p := s.Decompose(id)
+	if !p.Time.Equal(layout.Epoch.Add(time.Minute)) || p.Timestamp != time.Minute.Milliseconds() {
+		t.Fatalf("unexpected time in %+v", p)
+	}
+	if p.DatacenterID != 1 || p.WorkerID != 1000 || p.Sequence != 0 {
+		t.Fatalf("unexpected device in %+v", p)
+	}
+
+	// Only the time is needed to compose an ID.
+	cid, err := s.Compose(Parts{Time: p.Time, DatacenterID: 1, WorkerID: 1000})
+	if err != nil {
+		t.Fatalf("error composing, %s", err)
+	}
+	if cid != id {
+		t.Fatalf("Compose %d != %d", cid, id)
+	}
+}
+
+func TestComposeRange(t *testing.T) {
+	tt := []Parts{
+		{Timestamp: -1},
+		{Timestamp: timestampMax + 1},
+		{DatacenterID: datacenterIDMax + 1},
+		{WorkerID: -1},
+		{Sequence: sequenceMask + 1},
+		{Time: epochTime.Add(-time.Millisecond)},
+		{Time: epochTime.Add(time.Second), Timestamp: 1},
+		{Time: time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{Time: time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, p := range tt {
+		if _, err := Compose(p); err == nil {
+			t.Fatalf("no error composing %+v", p)
+		}
+	}
+
+	if _, err := Compose(Parts{Time: time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC)}); err != ErrTimeOutOfRange {
+		t.Fatalf("expected ErrTimeOutOfRange, got %v", err)
+	}
+
+	id, err := Compose(Parts{Timestamp: timestampMax, DatacenterID: datacenterIDMax, WorkerID: workerIDMax, Sequence: sequenceMask})
+	if err != nil {
+		t.Fatalf("error composing, %s", err)
+	}
+	if id != ID(1<<63-1) {
+		t.Fatalf("Compose %d != %d", id, ID(1<<63-1))
+	}
+}
+
+func TestPartsLateTimes(t *testing.T) {
+	// Both layouts hold creation times after 2262, which no longer fit in
+	// UnixNano.
+	layouts := []Layout{
+		{TimestampBits: 52, DatacenterIDBits: 1, WorkerIDBits: 1, SequenceBits: 9},
+		DefaultLayout.WithEpoch(time.Date(2250, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	for _, layout := range layouts {
+		p := layout.Decompose(math.MaxInt64)
+		if p.Time.Year() <= 2262 {
+			t.Fatalf("layout %+v: time %s is not after 2262", layout, p.Time)
+		}
+		if ms, _ := unixMillis(p.Time); ms != layout.Time(math.MaxInt64) {
+			t.Fatalf("layout %+v: time %d != %d", layout, ms, layout.Time(math.MaxInt64))
+		}
+
+		id, err := layout.Compose(p)
+		if err != nil {
+			t.Fatalf("layout %+v: error composing, %s", layout, err)
+		}
+		if id != math.MaxInt64 {
+			t.Fatalf("layout %+v: Compose %d != %d", layout, id, ID(math.MaxInt64))
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// prefixSeparator separates the prefix from the encoded ID
+const prefixSeparator = '_'
+
+// ErrInvalidPrefix is returned by RegisterPrefix when the name is empty or
+// has characters other than lowercase ASCII letters and digits, and by the
+// prefix parsers when a value has no prefix
+var ErrInvalidPrefix = errors.New("invalid snowflake ID prefix")
+
+// ErrDuplicatePrefix is returned by RegisterPrefix when the name is already
+// registered
+var ErrDuplicatePrefix = errors.New("duplicate snowflake ID prefix")
+
+// ErrUnknownPrefix is returned by ParsePrefixed when the prefix of a value
+// is not registered
+var ErrUnknownPrefix = errors.New("unknown snowflake ID prefix")
+
+// ErrPrefixMismatch matches every PrefixMismatchError with errors.Is
+var ErrPrefixMismatch = errors.New("snowflake ID prefix mismatch")
+
+// A PrefixMismatchError is returned by Prefix.Parse when a value carries
+// another prefix than the expected one.
+type PrefixMismatchError struct {
+	Expected string
+	Got      string
+}
+
+func (e *PrefixMismatchError) Error() string {
+	return fmt.Sprintf("snowflake ID prefix %q, want %q", e.Got, e.Expected)
+}
+
+// Is reports whether target is ErrPrefixMismatch
+func (e *PrefixMismatchError) Is(target error) bool {
+	return target == ErrPrefixMismatch
+}
+
+// A Prefix formats snowflake IDs of one type of entity as its name, an
+// underscore and the ID in its Encoding, such as "usr_2Vh8kQ", and parses
+// them back. Prefixes are created with RegisterPrefix.
+type Prefix struct {
+	name string
+	enc  *Encoding
+}
+
+var prefixes = struct {
+	sync.RWMutex
+	m map[string]*Prefix
+}{m: make(map[string]*Prefix)}
+
+// RegisterPrefix registers name as the prefix of an entity type whose IDs are
+// written in enc; a nil enc means Base58Encoding. The name must be unique and
+// made of lowercase ASCII letters and digits.
+func RegisterPrefix(name string, enc *Encoding) (*Prefix, error) {
+	if !validPrefix(name) {
+		return nil, ErrInvalidPrefix
+	}
+	if enc == nil {
+		enc = Base58Encoding
+	}
+
+	prefixes.Lock()
+	defer prefixes.Unlock()
+
+	if _, ok := prefixes.m[name]; ok {
+		return nil, ErrDuplicatePrefix
+	}
+	p := &Prefix{name: name, enc: enc}
+	prefixes.m[name] = p
+	return p, nil
+}
+
+// MustRegisterPrefix is like RegisterPrefix but panics on error. It simplifies
+// the initialization of package-level prefix variables.
+func MustRegisterPrefix(name string, enc *Encoding) *Prefix {
+	p, err := RegisterPrefix(name, enc)
+	if err != nil {
+		panic(fmt.Sprintf("snowflake: RegisterPrefix(%q): %s", name, err))
+	}
+	return p
+}
+
+// LookupPrefix returns the registered prefix with the name
+func LookupPrefix(name string) (*Prefix, bool) {
+	prefixes.RLock()
+	defer prefixes.RUnlock()
+
+	p, ok := prefixes.m[name]
+	return p, ok
+}
+
+func validPrefix(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Name returns the name of the prefix
+func (p *Prefix) Name() string {
+	return p.name
+}
+
+// Encoding returns the Encoding the prefix writes IDs in
+func (p *Prefix) Encoding() *Encoding {
+	return p.enc
+}
+
+// Format returns the prefixed string of the snowflake ID
+func (p *Prefix) Format(sid ID) string {
+	return string(p.Append(make([]byte, 0, len(p.name)+24), sid))
+}
+
+// Append appends the prefixed string of the snowflake ID to dst and returns
+// the extended buffer
+func (p *Prefix) Append(dst []byte, sid ID) []byte {
+	dst = append(dst, p.name...)
+	dst = append(dst, prefixSeparator)
+	return p.enc.Append(dst, sid)
+}
+
+// Parse parses a prefixed string into a snowflake ID, returning a
+// *PrefixMismatchError when it carries another prefix
+func (p *Prefix) Parse(id string) (ID, error) {
+	name, rest, err := splitPrefix(id)
+	if err != nil {
+		return -1, err
+	}
+	if name != p.name {
+		return -1, &PrefixMismatchError{Expected: p.name, Got: name}
+	}
+	return p.enc.DecodeString(rest)
+}
+
+// A PrefixedID is a snowflake ID together with the prefix of its entity type
+type PrefixedID struct {
+	Prefix *Prefix
+	ID     ID
+}
+
+// ParsePrefixed parses a string with any registered prefix
+func ParsePrefixed(id string) (PrefixedID, error) {
+	name, rest, err := splitPrefix(id)
+	if err != nil {
+		return PrefixedID{}, err
+	}
+	p, ok := LookupPrefix(name)
+	if !ok {
+		return PrefixedID{}, ErrUnknownPrefix
+	}
+	sid, err := p.enc.DecodeString(rest)
+	if err != nil {
+		return PrefixedID{}, err
+	}
+	return PrefixedID{Prefix: p, ID: sid}, nil
+}
+
+func splitPrefix(id string) (name, rest string, err error) {
+	if id == "" {
+		return "", "", ErrEmpty
+	}
+	i := strings.IndexByte(id, prefixSeparator)
+	if i <= 0 {
+		return "", "", ErrInvalidPrefix
+	}
+	return id[:i], id[i+1:], nil
+}
+
+// String returns the prefixed string of the ID, or the decimal ID when it
+// has no prefix
+func (p PrefixedID) String() string {
+	if p.Prefix == nil {
+		return p.ID.String()
+	}
+	return p.Prefix.Format(p.ID)
+}
+
+// MarshalText implements encoding.TextMarshaler, writing the prefixed string
+func (p PrefixedID) MarshalText() ([]byte, error) {
+	if p.Prefix == nil {
+		return nil, ErrInvalidPrefix
+	}
+	return p.Prefix.Append(nil, p.ID), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any registered
+// prefix
+func (p *PrefixedID) UnmarshalText(b []byte) error {
+	id, err := ParsePrefixed(string(b))
+	if err != nil {
+		return err
+	}
+	*p = id
+	return nil
+}
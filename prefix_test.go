@@ -0,0 +1,165 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var (
+	testUserPrefix  = MustRegisterPrefix("usr", nil)
+	testOrderPrefix = MustRegisterPrefix("ord", Base62Encoding)
+)
+
+func TestPrefixFormatParse(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+	id := node.NextVal()
+
+	s := testUserPrefix.Format(id)
+	if want := "usr_" + id.Base58(); s != want {
+		t.Fatalf("Format %s != %s", s, want)
+	}
+	pID, err := testUserPrefix.Parse(s)
+	if err != nil {
+		t.Fatalf("error parsing %s, %s", s, err)
+	}
+	if pID != id {
+		t.Fatalf("pID %v != oID %v", pID, id)
+	}
+
+	s = testOrderPrefix.Format(id)
+	if want := "ord_" + id.Base62(); s != want {
+		t.Fatalf("Format %s != %s", s, want)
+	}
+	if got := string(testOrderPrefix.Append([]byte("id="), id)); got != "id="+s {
+		t.Fatalf("Append %s != id=%s", got, s)
+	}
+}
+
+func TestPrefixMismatch(t *testing.T) {
+	s := testOrderPrefix.Format(42)
+
+	_, err := testUserPrefix.Parse(s)
+	var mismatch *PrefixMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("error %v is not a *PrefixMismatchError", err)
+	}
+	if mismatch.Expected != "usr" || mismatch.Got != "ord" {
+		t.Fatalf("mismatch %+v", mismatch)
+	}
+	if !errors.Is(err, ErrPrefixMismatch) {
+		t.Fatalf("error %v is not ErrPrefixMismatch", err)
+	}
+
+	tests := []struct {
+		id  string
+		err error
+	}{
+		{"", ErrEmpty},
+		{"usr", ErrInvalidPrefix},
+		{"_abc", ErrInvalidPrefix},
+		{"usr_", ErrEmpty},
+		{"usr_0", ErrInvalidBase58},
+	}
+	for _, tt := range tests {
+		if _, err := testUserPrefix.Parse(tt.id); err != tt.err {
+			t.Errorf("Parse(%q) error %v != %v", tt.id, err, tt.err)
+		}
+	}
+}
+
+func TestParsePrefixed(t *testing.T) {
+	p, err := ParsePrefixed(testOrderPrefix.Format(13587))
+	if err != nil {
+		t.Fatalf("error parsing, %s", err)
+	}
+	if p.Prefix != testOrderPrefix || p.ID != 13587 {
+		t.Fatalf("ParsePrefixed %v %v", p.Prefix.Name(), p.ID)
+	}
+	if p.String() != "ord_3X9" {
+		t.Fatalf("String %s != ord_3X9", p)
+	}
+
+	if s := fmt.Sprint(PrefixedID{ID: 13587}); s != "13587" {
+		t.Fatalf("String without prefix %s != 13587", s)
+	}
+
+	if _, err := ParsePrefixed("xyz_abc"); err != ErrUnknownPrefix {
+		t.Fatalf("error %v != %v", err, ErrUnknownPrefix)
+	}
+}
+
+func TestRegisterPrefixErrors(t *testing.T) {
+	for _, name := range []string{"", "Usr", "us_r", "us-r", "ü"} {
+		if _, err := RegisterPrefix(name, nil); err != ErrInvalidPrefix {
+			t.Errorf("RegisterPrefix(%q) error %v != %v", name, err, ErrInvalidPrefix)
+		}
+	}
+	if _, err := RegisterPrefix("usr", nil); err != ErrDuplicatePrefix {
+		t.Fatalf("error %v != %v", err, ErrDuplicatePrefix)
+	}
+
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "usr") {
+			t.Fatalf("MustRegisterPrefix recovered %v", r)
+		}
+	}()
+	MustRegisterPrefix("usr", nil)
+}
+
+func TestLookupPrefix(t *testing.T) {
+	if p, ok := LookupPrefix("usr"); !ok || p != testUserPrefix {
+		t.Fatalf("LookupPrefix(usr) = %v, %v", p, ok)
+	}
+	if _, ok := LookupPrefix("nope"); ok {
+		t.Fatalf("LookupPrefix(nope) found a prefix")
+	}
+	if testUserPrefix.Encoding() != Base58Encoding {
+		t.Fatalf("default encoding is not Base58Encoding")
+	}
+}
+
+func TestPrefixedIDJSON(t *testing.T) {
+	type order struct {
+		ID PrefixedID `json:"id"`
+	}
+	o := order{ID: PrefixedID{Prefix: testOrderPrefix, ID: 13587}}
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("error marshaling, %s", err)
+	}
+	if string(b) != `{"id":"ord_3X9"}` {
+		t.Fatalf("json %s", b)
+	}
+
+	var got order
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("error unmarshaling, %s", err)
+	}
+	if got != o {
+		t.Fatalf("unmarshaled %+v != %+v", got, o)
+	}
+
+	if _, err := json.Marshal(order{}); err == nil {
+		t.Fatalf("marshaling a PrefixedID without prefix succeeded")
+	}
+}
+
+func BenchmarkPrefixFormat(b *testing.B) {
+
+	node, _ := NewSnowflake(1, 1)
+	sf := node.NextVal()
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		testUserPrefix.Format(sf)
+	}
+}
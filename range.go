@@ -0,0 +1,108 @@
+package snowflake
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ErrTimeOutOfRange is returned when a time is before the epoch of a layout
+// or too late to fit in its timestamp bits
+var ErrTimeOutOfRange = errors.New("time out of range")
+
+// IDRange is an inclusive range of snowflake IDs, typically covering every ID
+// that can be generated during a time window. It can be used to query IDs
+// created between two times with an index on the ID alone.
+type IDRange struct {
+	Min ID // Smallest ID in the range
+	Max ID // Largest ID in the range
+}
+
+// Contains reports whether the snowflake ID is in the range
+func (r IDRange) Contains(sid ID) bool {
+	return sid >= r.Min && sid <= r.Max
+}
+
+// Overlaps reports whether the two ranges have at least one ID in common
+func (r IDRange) Overlaps(o IDRange) bool {
+	return r.Min <= o.Max && o.Min <= r.Max
+}
+
+// unixMillis returns t as a unix timestamp in milliseconds, rounded down, or
+// false when it does not fit in an int64. Unlike UnixNano it covers every
+// year a timestamp can hold.
+func unixMillis(t time.Time) (int64, bool) {
+	sec := t.Unix()
+	if sec < math.MinInt64/1000 || sec > (math.MaxInt64-999)/1000 {
+		return 0, false
+	}
+	return sec*1000 + int64(t.Nanosecond())/int64(time.Millisecond), true
+}
+
+// timestamp returns the raw timestamp field for t
+func (l Layout) timestamp(t time.Time) (int64, error) {
+	ms, ok := unixMillis(t)
+	e := l.EpochMillis()
+	// ts < 0 catches the subtraction overflowing for times far after a
+	// pre-1970 epoch.
+	ts := ms - e
+	if !ok || ms < e || ts < 0 || ts > l.TimestampMax() {
+		return 0, ErrTimeOutOfRange
+	}
+	return ts, nil
+}
+
+// MinID returns the smallest snowflake ID that can be generated during the
+// millisecond of t
+func (l Layout) MinID(t time.Time) (ID, error) {
+	ts, err := l.timestamp(t)
+	if err != nil {
+		return 0, err
+	}
+	return ID(ts << l.timestampShift()), nil
+}
+
+// MaxID returns the largest snowflake ID that can be generated during the
+// millisecond of t
+func (l Layout) MaxID(t time.Time) (ID, error) {
+	ts, err := l.timestamp(t)
+	if err != nil {
+		return 0, err
+	}
+	return ID(ts<<l.timestampShift() | (int64(1)<<l.timestampShift() - 1)), nil
+}
+
+// Range returns the range of snowflake IDs that can be generated from the
+// millisecond of from up to and including the millisecond of to
+func (l Layout) Range(from, to time.Time) (IDRange, error) {
+	if to.Before(from) {
+		return IDRange{}, errors.New("range ends before it starts")
+	}
+	min, err := l.MinID(from)
+	if err != nil {
+		return IDRange{}, err
+	}
+	max, err := l.MaxID(to)
+	if err != nil {
+		return IDRange{}, err
+	}
+	return IDRange{Min: min, Max: max}, nil
+}
+
+// MinIDForTime returns the smallest snowflake ID of the default layout that
+// can be generated during the millisecond of t
+func MinIDForTime(t time.Time) (ID, error) {
+	return DefaultLayout.MinID(t)
+}
+
+// MaxIDForTime returns the largest snowflake ID of the default layout that
+// can be generated during the millisecond of t
+func MaxIDForTime(t time.Time) (ID, error) {
+	return DefaultLayout.MaxID(t)
+}
+
+// NewIDRange returns the range of snowflake IDs of the default layout that
+// can be generated between from and to, both included
+func NewIDRange(from, to time.Time) (IDRange, error) {
+	return DefaultLayout.Range(from, to)
+}
@@ -0,0 +1,149 @@
+package snowflake
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMinMaxIDForTime(t *testing.T) {
+	at := epochTime.Add(time.Hour + 500*time.Microsecond)
+	c := NewFakeClock(at)
+	s, err := NewSnowflake(28, 11, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+
+	min, err := MinIDForTime(at)
+	if err != nil {
+		t.Fatalf("error getting min ID, %s", err)
+	}
+	max, err := MaxIDForTime(at)
+	if err != nil {
+		t.Fatalf("error getting max ID, %s", err)
+	}
+	if GetTimestamp(min) != GetTimestamp(max) || GetTimestamp(min) != time.Hour.Milliseconds() {
+		t.Fatalf("min %d and max %d are not in the same millisecond", min, max)
+	}
+
+	for i := 0; i < 100; i++ {
+		id := s.NextVal()
+		if id < min || id > max {
+			t.Fatalf("id %d not in [%d, %d]", id, min, max)
+		}
+	}
+
+	next, _ := MinIDForTime(at.Add(time.Millisecond))
+	if next != max+1 {
+		t.Fatalf("next millisecond min %d != %d", next, max+1)
+	}
+
+	if _, err = MinIDForTime(epochTime.Add(-time.Millisecond)); err != ErrTimeOutOfRange {
+		t.Fatalf("expected ErrTimeOutOfRange, got %v", err)
+	}
+	if _, err = MaxIDForTime(epochTime.Add(time.Duration(timestampMax+1) * time.Millisecond)); err != ErrTimeOutOfRange {
+		t.Fatalf("expected ErrTimeOutOfRange, got %v", err)
+	}
+}
+
+func TestIDRange(t *testing.T) {
+	from := epochTime.Add(time.Hour)
+	to := from.Add(time.Minute)
+
+	r, err := NewIDRange(from, to)
+	if err != nil {
+		t.Fatalf("error creating range, %s", err)
+	}
+
+	tt := []struct {
+		at       time.Time
+		contains bool
+	}{
+		{from.Add(-time.Millisecond), false},
+		{from, true},
+		{from.Add(30 * time.Second), true},
+		{to.Add(999 * time.Microsecond), true},
+		{to.Add(time.Millisecond), false},
+	}
+	for _, tc := range tt {
+		s, _ := NewSnowflake(31, 31, WithClock(NewFakeClock(tc.at)))
+		if id := s.NextVal(); r.Contains(id) != tc.contains {
+			t.Fatalf("Contains(%s) != %v", tc.at, tc.contains)
+		}
+	}
+
+	later, _ := NewIDRange(to, to.Add(time.Minute))
+	after, _ := NewIDRange(to.Add(time.Millisecond), to.Add(time.Minute))
+	if !r.Overlaps(later) || !later.Overlaps(r) {
+		t.Fatalf("ranges sharing a millisecond do not overlap")
+	}
+	if r.Overlaps(after) || after.Overlaps(r) {
+		t.Fatalf("disjoint ranges overlap")
+	}
+
+	if _, err = NewIDRange(to, from); err == nil {
+		t.Fatalf("no error creating a reversed range")
+	}
+}
+
+func TestLayoutRange(t *testing.T) {
+	layout := Layout{TimestampBits: 43, DatacenterIDBits: 2, WorkerIDBits: 8, SequenceBits: 10, Epoch: time.Now().Add(-time.Hour)}
+	s, err := NewSnowflakeWithLayout(layout, 3, 255)
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+
+	from := time.Now()
+	id := s.NextVal()
+	r, err := layout.Range(from, time.Now())
+	if err != nil {
+		t.Fatalf("error creating range, %s", err)
+	}
+	if !r.Contains(id) {
+		t.Fatalf("range %+v does not contain %d", r, id)
+	}
+	if _, err = layout.Range(from.Add(-2*time.Hour), from); err != ErrTimeOutOfRange {
+		t.Fatalf("expected ErrTimeOutOfRange, got %v", err)
+	}
+}
+
+func TestTimeOutOfRange(t *testing.T) {
+	// These times do not fit in UnixNano, which must not wrap them into range.
+	times := []time.Time{
+		time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for _, at := range times {
+		if id, err := MinIDForTime(at); err != ErrTimeOutOfRange {
+			t.Errorf("MinIDForTime(%s) = %d, %v", at, id, err)
+		}
+	}
+
+	// With 62 timestamp bits the range is only limited by int64 milliseconds.
+	layout := Layout{TimestampBits: 62, SequenceBits: 1, Epoch: time.Date(1700, 1, 1, 0, 0, 0, 0, time.UTC)}
+	if _, err := layout.MaxID(time.Date(3000, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("error getting max ID, %s", err)
+	}
+	times = []time.Time{
+		time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Unix(-1<<62, 0),
+		time.Unix(1<<62, 0),
+	}
+	for _, at := range times {
+		if id, err := layout.MaxID(at); err != ErrTimeOutOfRange {
+			t.Errorf("MaxID(%s) = %d, %v", at, id, err)
+		}
+	}
+}
+
+func TestMinIDBeforeUnixEpoch(t *testing.T) {
+	// Half a millisecond before 1970 belongs to the millisecond before, so
+	// the timestamp must round down rather than toward zero.
+	layout := DefaultLayout.WithEpoch(time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC))
+	id, err := layout.MinID(time.Unix(0, -500000))
+	if err != nil {
+		t.Fatalf("error getting min ID, %s", err)
+	}
+	if ts, want := layout.Timestamp(id), -1-layout.EpochMillis(); ts != want {
+		t.Fatalf("timestamp %d != %d", ts, want)
+	}
+}
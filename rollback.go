@@ -0,0 +1,47 @@
+package snowflake
+
+import (
+	"errors"
+	"time"
+)
+
+// DefaultMaxClockDrift is how far the clock may move backwards before a
+// Snowflake using RollbackWait gives up waiting and fails.
+const DefaultMaxClockDrift = 10 * time.Millisecond
+
+// ErrClockMovedBackwards is returned when the clock moved backwards and the
+// rollback strategy refuses to generate an ID.
+var ErrClockMovedBackwards = errors.New("clock moved backwards")
+
+// RollbackStrategy decides what a Snowflake does when its clock reports a
+// time earlier than the last ID it generated, e.g. after an NTP correction.
+type RollbackStrategy int
+
+const (
+	// RollbackWait blocks until the clock catches up with the last timestamp,
+	// as long as the drift does not exceed the maximum tolerated drift.
+	// Larger drifts fail with ErrClockMovedBackwards. This is the default.
+	RollbackWait RollbackStrategy = iota
+
+	// RollbackError fails with ErrClockMovedBackwards as soon as the clock
+	// moves backwards.
+	RollbackError
+
+	// RollbackLogical keeps issuing IDs from the last-seen timestamp, moving
+	// it forward by one millisecond whenever its sequence is exhausted, until
+	// the clock catches up again.
+	RollbackLogical
+)
+
+// String returns the name of the rollback strategy
+func (r RollbackStrategy) String() string {
+	switch r {
+	case RollbackWait:
+		return "wait"
+	case RollbackError:
+		return "error"
+	case RollbackLogical:
+		return "logical"
+	}
+	return "unknown"
+}
@@ -0,0 +1,106 @@
+package snowflake
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func newRollbackSnowflake(t *testing.T, c *FakeClock, opts ...Option) *Snowflake {
+	s, err := NewSnowflake(1, 1, append([]Option{WithClock(c)}, opts...)...)
+	if err != nil {
+		t.Fatalf("error creating snowflake, %s", err)
+	}
+	return s
+}
+
+func TestRollbackError(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Hour))
+	s := newRollbackSnowflake(t, c, WithRollbackStrategy(RollbackError))
+
+	x, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	c.Advance(-time.Millisecond)
+	if _, err = s.Next(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Fatalf("expected ErrClockMovedBackwards, got %v", err)
+	}
+
+	c.Advance(time.Millisecond)
+	y, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	if y != x+1 {
+		t.Fatalf("y(%d) != x(%d)+1", y, x)
+	}
+}
+
+func TestRollbackWait(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Hour))
+	s := newRollbackSnowflake(t, c, WithMaxClockDrift(5*time.Millisecond))
+
+	x, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+
+	// Within the tolerated drift the generator waits for the clock.
+	c.Advance(-5 * time.Millisecond)
+	y, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	if y != x+1 {
+		t.Fatalf("y(%d) != x(%d)+1", y, x)
+	}
+
+	// Beyond it the generator fails.
+	c.Advance(-10 * time.Millisecond)
+	if _, err = s.Next(); !errors.Is(err, ErrClockMovedBackwards) {
+		t.Fatalf("expected ErrClockMovedBackwards, got %v", err)
+	}
+}
+
+func TestRollbackLogical(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Hour))
+	s := newRollbackSnowflake(t, c, WithRollbackStrategy(RollbackLogical))
+
+	x, err := s.Next()
+	if err != nil {
+		t.Fatalf("unexpected error, %s", err)
+	}
+	last := GetTimestamp(x)
+
+	c.Advance(-time.Second)
+	for i := 0; i < 3*int(sequenceMask); i++ {
+		y, err := s.Next()
+		if err != nil {
+			t.Fatalf("unexpected error, %s", err)
+		}
+		if y <= x {
+			t.Fatalf("y(%d) <= x(%d)", y, x)
+		}
+		x = y
+	}
+
+	// The clock never moved, so the sequence borrowed the next milliseconds.
+	if ts := GetTimestamp(x); ts != last+2 {
+		t.Fatalf("timestamp %d != %d", ts, last+2)
+	}
+}
+
+func TestRollbackStrategyString(t *testing.T) {
+	for strategy, name := range map[RollbackStrategy]string{
+		RollbackWait:        "wait",
+		RollbackError:       "error",
+		RollbackLogical:     "logical",
+		RollbackStrategy(9): "unknown",
+	} {
+		if strategy.String() != name {
+			t.Fatalf("String %q != %q", strategy.String(), name)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package snowflake
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/binary"
 	"errors"
@@ -31,11 +32,9 @@ type ID int64
 
 const encodeBase32Map = "ybndrfg8ejkmcpqxot1uwisza345h769"
 
-var decodeBase32Map [256]byte
-
 const encodeBase58Map = "123456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
 
-var decodeBase58Map [256]byte
+const encodeBase62Map = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 
 // A JSONSyntaxError is returned from UnmarshalJSON if an invalid ID is provided.
 type JSONSyntaxError struct{ original []byte }
@@ -50,101 +49,306 @@ var ErrInvalidBase58 = errors.New("invalid base58")
 // ErrInvalidBase32 is returned by ParseBase32 when given an invalid []byte
 var ErrInvalidBase32 = errors.New("invalid base32")
 
-// Create maps for decoding Base58/Base32.
-// This speeds up the process tremendously.
-func init() {
+// ErrInvalidBase62 is returned by ParseBase62 when given an invalid []byte
+var ErrInvalidBase62 = errors.New("invalid base62")
 
-	for i := 0; i < len(encodeBase58Map); i++ {
-		decodeBase58Map[i] = 0xFF
-	}
+// ErrOverflow is returned by the parsers when a value does not fit in a snowflake ID
+var ErrOverflow = errors.New("snowflake ID overflows int64")
 
-	for i := 0; i < len(encodeBase58Map); i++ {
-		decodeBase58Map[encodeBase58Map[i]] = byte(i)
-	}
+// ErrEmpty is returned by the parsers when given an empty value
+var ErrEmpty = errors.New("empty snowflake ID")
+
+// ErrNegative is returned by the parsers when a value is a negative number,
+// which is never a valid snowflake ID
+var ErrNegative = errors.New("negative snowflake ID")
+
+// ErrInvalidBinary is returned by UnmarshalBinary when not given exactly 8 bytes
+var ErrInvalidBinary = errors.New("invalid binary snowflake ID")
 
-	for i := 0; i < len(encodeBase32Map); i++ {
-		decodeBase32Map[i] = 0xFF
+// ErrEpochExhausted is returned by Next when the timestamp no longer fits in
+// the bits reserved for it by the layout
+var ErrEpochExhausted = errors.New("epoch exhausted")
+
+// ErrBeforeEpoch is returned by Next when the clock is earlier than the epoch
+var ErrBeforeEpoch = errors.New("clock is before the epoch")
+
+// ErrContextCanceled is returned by context-aware generation when the context
+// is canceled or its deadline passes before an ID could be generated
+var ErrContextCanceled = errors.New("context canceled")
+
+// contextError reports why the context ended; it matches both
+// ErrContextCanceled and the error of the context with errors.Is.
+type contextError struct{ err error }
+
+func (c contextError) Error() string {
+	return c.err.Error()
+}
+
+func (c contextError) Is(target error) bool {
+	return target == ErrContextCanceled
+}
+
+func (c contextError) Unwrap() error {
+	return c.err
+}
+
+// node holds the settings shared by the snowflake generators
+type node struct {
+	workerID     int64
+	datacenterID int64
+
+	clock             Clock
+	rollback          RollbackStrategy
+	maxDrift          int64
+	layout            Layout
+	epoch             int64
+	timestampMax      int64
+	sequenceMask      int64
+	workerIDShift     uint
+	datacenterIDShift uint
+	timestampShift    uint
+}
+
+func newNode(layout Layout, datacenterID, workerID int64, opts []Option) (node, error) {
+	if err := layout.Validate(); err != nil {
+		return node{}, err
+	}
+	if datacenterID < 0 || datacenterID > layout.DatacenterIDMax() {
+		return node{}, fmt.Errorf("datacenterid must be between 0 and %d", layout.DatacenterIDMax())
 	}
+	if workerID < 0 || workerID > layout.WorkerIDMax() {
+		return node{}, fmt.Errorf("workerid must be between 0 and %d", layout.WorkerIDMax())
+	}
+	o := newOptions(opts)
+	return node{
+		datacenterID:      datacenterID,
+		workerID:          workerID,
+		clock:             o.clock,
+		rollback:          o.rollback,
+		maxDrift:          o.maxDrift.Milliseconds(),
+		layout:            layout,
+		epoch:             layout.EpochMillis(),
+		timestampMax:      layout.TimestampMax(),
+		sequenceMask:      layout.SequenceMask(),
+		workerIDShift:     layout.workerIDShift(),
+		datacenterIDShift: layout.datacenterIDShift(),
+		timestampShift:    layout.timestampShift(),
+	}, nil
+}
+
+// Layout returns the bit layout used by the snowflake node
+func (n *node) Layout() Layout {
+	return n.layout
+}
 
-	for i := 0; i < len(encodeBase32Map); i++ {
-		decodeBase32Map[encodeBase32Map[i]] = byte(i)
+// now returns the current time of the snowflake clock in milliseconds
+func (n *node) now() int64 {
+	return n.clock.Now().UnixNano() / 1000000
+}
+
+// wait sleeps until the clock reaches ms or ctx is done, and returns how
+// long it waited
+func (n *node) wait(ctx context.Context, ms int64) (time.Duration, error) {
+	start := n.clock.Now()
+	d := time.Duration(ms*1000000 - start.UnixNano())
+	if d <= 0 {
+		return 0, nil
 	}
+	select {
+	case <-ctx.Done():
+		return n.clock.Now().Sub(start), contextError{ctx.Err()}
+	case <-n.clock.After(d):
+	}
+	return n.clock.Now().Sub(start), nil
+}
+
+// rolledBack returns the error reported when the clock moved back by drift milliseconds
+func (n *node) rolledBack(drift int64) error {
+	return fmt.Errorf("%w by %dms", ErrClockMovedBackwards, drift)
+}
+
+// compose builds an ID from a timestamp relative to the epoch and a sequence
+func (n *node) compose(t, sequence int64) ID {
+	return ID((t)<<n.timestampShift | (n.datacenterID << n.datacenterIDShift) | (n.workerID << n.workerIDShift) | (sequence))
 }
 
 // Snowflake is a custom type
 type Snowflake struct {
 	sync.Mutex
-	timestamp    int64
-	workerID     int64
-	datacenterID int64
-	sequence     int64
+	node
+	timestamp int64
+	sequence  int64
 }
 
 // NewSnowflake returns a new snowflake node that can be used to generate snowflake
-func NewSnowflake(datacenterID, workerID int64) (*Snowflake, error) {
-	if datacenterID < 0 || datacenterID > datacenterIDMax {
-		return nil, fmt.Errorf("datacenterid must be between 0 and %d", datacenterIDMax-1)
-	}
-	if workerID < 0 || workerID > workerIDMax {
-		return nil, fmt.Errorf("workerid must be between 0 and %d", workerIDMax-1)
+func NewSnowflake(datacenterID, workerID int64, opts ...Option) (*Snowflake, error) {
+	return NewSnowflakeWithLayout(DefaultLayout, datacenterID, workerID, opts...)
+}
+
+// NewSnowflakeWithLayout returns a new snowflake node that splits the bits of
+// the generated IDs according to layout and counts their time from its epoch
+func NewSnowflakeWithLayout(layout Layout, datacenterID, workerID int64, opts ...Option) (*Snowflake, error) {
+	n, err := newNode(layout, datacenterID, workerID, opts)
+	if err != nil {
+		return nil, err
 	}
 	return &Snowflake{
-		timestamp:    0,
-		datacenterID: datacenterID,
-		workerID:     workerID,
-		sequence:     0,
+		node:      n,
+		timestamp: 0,
+		sequence:  0,
 	}, nil
 }
 
-// NextVal creates and returns a unique snowflake ID
+// NextVal creates and returns a unique snowflake ID, or 0 if it fails.
+// Use Next to find out why an ID could not be generated.
+func (s *Snowflake) NextVal() ID {
+	r, err := s.Next()
+	if err != nil {
+		return 0
+	}
+	return r
+}
+
+// Next creates and returns a unique snowflake ID
 // To help guarantee uniqueness
 // - Make sure your system is keeping accurate system time
 // - Make sure you never have multiple nodes running with the same node ID
-func (s *Snowflake) NextVal() ID {
+//
+// It fails with ErrEpochExhausted once the epoch has run out, ErrBeforeEpoch
+// when the clock is earlier than the epoch and ErrClockMovedBackwards when
+// the rollback strategy refuses to generate an ID.
+func (s *Snowflake) Next() (ID, error) {
+	r, _, err := s.NextContext(context.Background())
+	return r, err
+}
+
+// NextContext creates and returns a unique snowflake ID like Next, along with
+// how long the caller waited for the clock. When the sequence is exhausted or
+// the clock has to catch up after a rollback, it releases the lock and sleeps
+// instead of spinning, and fails with ErrContextCanceled if ctx is done first.
+func (s *Snowflake) NextContext(ctx context.Context) (ID, time.Duration, error) {
+	var waited time.Duration
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, waited, contextError{err}
+		}
+		s.Lock()
+		r, until, err := s.tryNext()
+		s.Unlock()
+		if until == 0 {
+			return r, waited, err
+		}
+		w, err := s.wait(ctx, until)
+		waited += w
+		if err != nil {
+			return 0, waited, err
+		}
+	}
+}
+
+// NextBatch fills ids with unique snowflake IDs while holding the lock once.
+// The IDs are strictly increasing; when the sequence is exhausted the batch
+// continues in the next millisecond. It returns the number of IDs written,
+// which is less than len(ids) only if an error occurred.
+func (s *Snowflake) NextBatch(ids []ID) (int, error) {
 	s.Lock()
-	now := time.Now().UnixNano() / 1000000 // 转毫秒
+	defer s.Unlock()
+	for i := range ids {
+		r, err := s.next()
+		if err != nil {
+			return i, err
+		}
+		ids[i] = r
+	}
+	return len(ids), nil
+}
+
+// NextN returns n unique, strictly increasing snowflake IDs generated while
+// holding the lock once. On error it returns the IDs generated so far.
+func (s *Snowflake) NextN(n int) ([]ID, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	ids := make([]ID, n)
+	i, err := s.NextBatch(ids)
+	return ids[:i], err
+}
+
+// next generates an ID, sleeping while holding the lock if it has to wait.
+// The caller must hold the lock.
+func (s *Snowflake) next() (ID, error) {
+	for {
+		r, until, err := s.tryNext()
+		if until == 0 {
+			return r, err
+		}
+		if _, err = s.wait(context.Background(), until); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// tryNext generates an ID without waiting. If the clock has to move forward
+// first, it returns the time in milliseconds to wait for instead.
+// The caller must hold the lock.
+func (s *Snowflake) tryNext() (r ID, until int64, err error) {
+	now := s.now() // 转毫秒
+	logical := false
+	if now < s.timestamp {
+		// The clock moved backwards since the last ID was generated
+		drift := s.timestamp - now
+		switch s.rollback {
+		case RollbackLogical:
+			now, logical = s.timestamp, true
+		case RollbackWait:
+			if drift > s.maxDrift {
+				return 0, 0, s.rolledBack(drift)
+			}
+			return 0, s.timestamp, nil
+		default:
+			return 0, 0, s.rolledBack(drift)
+		}
+	}
+	sequence := int64(0)
 	if s.timestamp == now {
 		// When id is generated multiple times under the same timestamp (precision: millisecond), the sequence number will be increased.
-		s.sequence = (s.sequence + 1) & sequenceMask
-		if s.sequence == 0 {
+		sequence = (s.sequence + 1) & s.sequenceMask
+		if sequence == 0 {
 			// If the current sequence exceeds the 12bit length, you need to wait for the next millisecond
 			// Sequence:0 will be used in the next millisecond
-			for now <= s.timestamp {
-				now = time.Now().UnixNano() / 1000000
+			if !logical {
+				return 0, s.timestamp + 1, nil
 			}
+			now = s.timestamp + 1
 		}
-	} else {
-		// Use the serial number directly under different timestamps (precision: milliseconds): 0
-		s.sequence = 0
 	}
-	t := now - epoch
-	if t > timestampMax {
-		s.Unlock()
-		fmt.Printf("epoch must be between 0 and %d", timestampMax-1)
-		return 0
+	// Use the serial number directly under different timestamps (precision: milliseconds): 0
+	t := now - s.epoch
+	if t < 0 {
+		return 0, 0, ErrBeforeEpoch
+	}
+	if t > s.timestampMax {
+		return 0, 0, ErrEpochExhausted
 	}
 	s.timestamp = now
-	r := ID((t)<<timestampShift | (s.datacenterID << datacenterIDShift) | (s.workerID << workerIDShift) | (s.sequence))
-	s.Unlock()
-	return r
+	s.sequence = sequence
+	return s.compose(t, sequence), 0, nil
 }
 
 // GetDeviceID returns an int64 of the snowflake center ID and machine ID number
 func GetDeviceID(sid int64) (datacenterID, workerID int64) {
-	datacenterID = (sid >> datacenterIDShift) & datacenterIDMax
-	workerID = (sid >> workerIDShift) & workerIDMax
-	return
+	return DefaultLayout.DeviceID(ID(sid))
 }
 
 // GetTimestamp returns an int64 unix timestamp in milliseconds of the snowflake ID time
 func GetTimestamp(sid ID) (timestamp int64) {
-	timestamp = (int64(sid) >> timestampShift) & timestampMax
+	timestamp = DefaultLayout.Timestamp(sid)
 	return
 }
 
 // GetGenTimestamp returns Get the timestamp when the ID was created
 func GetGenTimestamp(sid ID) (timestamp int64) {
-	timestamp = GetTimestamp(sid) + epoch
+	timestamp = DefaultLayout.Time(sid)
 	return
 }
 
@@ -155,9 +359,20 @@ func GetGenTime(sid ID) (t string) {
 	return
 }
 
+// GenTime returns the creation time of the snowflake ID in UTC (precision: milliseconds)
+func (sid ID) GenTime() time.Time {
+	return DefaultLayout.GenTime(sid)
+}
+
+// Format returns the creation time of the snowflake ID formatted with the
+// time layout in the location loc. A nil loc formats in UTC.
+func (sid ID) Format(layout string, loc *time.Location) string {
+	return DefaultLayout.Format(sid, layout, loc)
+}
+
 // GetTimestampStatus returns an float64 unix timestamp in milliseconds of the snowflake ID time Get the percentage of timestamps used: range (0.0-1.0)
 func GetTimestampStatus() (state float64) {
-	state = float64(time.Now().UnixNano()/1000000-epoch) / float64(timestampMax)
+	state = DefaultLayout.TimestampStatus()
 	return
 }
 
@@ -178,9 +393,25 @@ func (sid ID) String() string {
 
 // ParseString converts a string into a snowflake ID
 func ParseString(sid string) (ID, error) {
-	i, err := strconv.ParseInt(sid, 10, 64)
-	return ID(i), err
+	return parseInt(sid, 10)
+}
 
+// parseInt converts a string in the given base into a non-negative snowflake ID
+func parseInt(id string, base int) (ID, error) {
+	if len(id) == 0 {
+		return -1, ErrEmpty
+	}
+	if id[0] == '-' {
+		return -1, ErrNegative
+	}
+	i, err := strconv.ParseInt(id, base, 64)
+	if err != nil {
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			return -1, ErrOverflow
+		}
+		return -1, err
+	}
+	return ID(i), nil
 }
 
 // Base2 returns a string base2 of the snowflake ID
@@ -190,8 +421,7 @@ func (sid ID) Base2() string {
 
 // ParseBase2 converts a Base2 string into a snowflake ID
 func ParseBase2(id string) (ID, error) {
-	i, err := strconv.ParseInt(id, 2, 64)
-	return ID(i), err
+	return parseInt(id, 2)
 }
 
 // Base32 uses the z-base-32 character set but encodes and decodes similar
@@ -199,40 +429,14 @@ func ParseBase2(id string) (ID, error) {
 // NOTE: There are many different base32 implementations so becareful when
 // doing any interoperation.
 func (sid ID) Base32() string {
-
-	if sid < 32 {
-		return string(encodeBase32Map[sid])
-	}
-
-	b := make([]byte, 0, 12)
-	for sid >= 32 {
-		b = append(b, encodeBase32Map[sid%32])
-		sid /= 32
-	}
-	b = append(b, encodeBase32Map[sid])
-
-	for x, y := 0, len(b)-1; x < y; x, y = x+1, y-1 {
-		b[x], b[y] = b[y], b[x]
-	}
-
-	return string(b)
+	return string(sid.AppendBase32(make([]byte, 0, 13)))
 }
 
 // ParseBase32 parses a base32 []byte into a snowflake ID
 // NOTE: There are many different base32 implementations so becareful when
 // doing any interoperation.
 func ParseBase32(b []byte) (ID, error) {
-
-	var id int64
-
-	for i := range b {
-		if decodeBase32Map[b[i]] == 0xFF {
-			return -1, ErrInvalidBase32
-		}
-		id = id*32 + int64(decodeBase32Map[b[i]])
-	}
-
-	return ID(id), nil
+	return Base32Encoding.Decode(b)
 }
 
 // Base36 returns a base36 string of the snowflake ID
@@ -242,53 +446,40 @@ func (sid ID) Base36() string {
 
 // ParseBase36 converts a Base36 string into a snowflake ID
 func ParseBase36(id string) (ID, error) {
-	i, err := strconv.ParseInt(id, 36, 64)
-	return ID(i), err
+	return parseInt(id, 36)
 }
 
 // Base58 returns a base58 string of the snowflake ID
 func (sid ID) Base58() string {
-
-	if sid < 58 {
-		return string(encodeBase58Map[sid])
-	}
-
-	b := make([]byte, 0, 11)
-	for sid >= 58 {
-		b = append(b, encodeBase58Map[sid%58])
-		sid /= 58
-	}
-	b = append(b, encodeBase58Map[sid])
-
-	for x, y := 0, len(b)-1; x < y; x, y = x+1, y-1 {
-		b[x], b[y] = b[y], b[x]
-	}
-
-	return string(b)
+	return string(sid.AppendBase58(make([]byte, 0, 11)))
 }
 
 // ParseBase58 parses a base58 []byte into a snowflake ID
 func ParseBase58(b []byte) (ID, error) {
+	return Base58Encoding.Decode(b)
+}
 
-	var id int64
-
-	for i := range b {
-		if decodeBase58Map[b[i]] == 0xFF {
-			return -1, ErrInvalidBase58
-		}
-		id = id*58 + int64(decodeBase58Map[b[i]])
-	}
+// Base62 returns a base62 string of the snowflake ID, using the URL-safe
+// alphabet 0-9, A-Z, a-z
+func (sid ID) Base62() string {
+	return string(sid.AppendBase62(make([]byte, 0, 11)))
+}
 
-	return ID(id), nil
+// ParseBase62 parses a base62 []byte into a snowflake ID
+func ParseBase62(b []byte) (ID, error) {
+	return Base62Encoding.Decode(b)
 }
 
 // Base64 returns a base64 string of the snowflake ID
 func (sid ID) Base64() string {
-	return base64.StdEncoding.EncodeToString(sid.Bytes())
+	return string(sid.AppendBase64(make([]byte, 0, 28)))
 }
 
 // ParseBase64 converts a base64 string into a snowflake ID
 func ParseBase64(id string) (ID, error) {
+	if len(id) == 0 {
+		return -1, ErrEmpty
+	}
 	b, err := base64.StdEncoding.DecodeString(id)
 	if err != nil {
 		return -1, err
@@ -297,6 +488,55 @@ func ParseBase64(id string) (ID, error) {
 
 }
 
+// RawBase64 returns the 8 big endian bytes of the snowflake ID encoded with
+// unpadded standard base64. Unlike Base64 it encodes the binary form rather
+// than the decimal string, giving an 11 character result.
+func (sid ID) RawBase64() string {
+	return string(sid.AppendRawBase64(make([]byte, 0, 11)))
+}
+
+// ParseRawBase64 converts an unpadded standard base64 string of the 8 big
+// endian bytes of a snowflake ID into a snowflake ID
+func ParseRawBase64(id string) (ID, error) {
+	return parseBinaryBase64(strictRawStdEncoding, id)
+}
+
+// RawURLBase64 returns the 8 big endian bytes of the snowflake ID encoded with
+// unpadded URL-safe base64, giving an 11 character result.
+func (sid ID) RawURLBase64() string {
+	return string(sid.AppendRawURLBase64(make([]byte, 0, 11)))
+}
+
+// ParseRawURLBase64 converts an unpadded URL-safe base64 string of the 8 big
+// endian bytes of a snowflake ID into a snowflake ID
+func ParseRawURLBase64(id string) (ID, error) {
+	return parseBinaryBase64(strictRawURLEncoding, id)
+}
+
+// The binary base64 parsers decode strictly, rejecting non-zero padding bits
+// in the last character, so each ID has exactly one encoded form.
+var (
+	strictRawStdEncoding = base64.RawStdEncoding.Strict()
+	strictRawURLEncoding = base64.RawURLEncoding.Strict()
+)
+
+func parseBinaryBase64(enc *base64.Encoding, id string) (ID, error) {
+	if len(id) == 0 {
+		return -1, ErrEmpty
+	}
+	var b [8]byte
+	if enc.DecodedLen(len(id)) != len(b) {
+		return -1, ErrInvalidBinary
+	}
+	if _, err := enc.Decode(b[:], []byte(id)); err != nil {
+		return -1, err
+	}
+	if b[0]&0x80 != 0 {
+		return -1, ErrNegative
+	}
+	return ParseIntBytes(b), nil
+}
+
 // Bytes return a byte slice of the snowflake ID
 func (sid ID) Bytes() []byte {
 	return []byte(sid.String())
@@ -304,8 +544,7 @@ func (sid ID) Bytes() []byte {
 
 // ParseBytes converts a byte slice into a snowflake ID
 func ParseBytes(id []byte) (ID, error) {
-	i, err := strconv.ParseInt(string(id), 10, 64)
-	return ID(i), err
+	return parseInt(string(id), 10)
 }
 
 // IntBytes returns an array of bytes of the snowflake ID, encoded as a
@@ -326,47 +565,121 @@ func ParseIntBytes(id [8]byte) ID {
 func (sid ID) MarshalJSON() ([]byte, error) {
 	buff := make([]byte, 0, 22)
 	buff = append(buff, '"')
-	buff = strconv.AppendInt(buff, int64(sid), 10)
+	buff = sid.AppendString(buff)
 	buff = append(buff, '"')
 	return buff, nil
 }
 
 // UnmarshalJSON converts a json byte array of a snowflake ID into an ID type.
+// It accepts both a quoted string and a bare integer number, and like the
+// other decoders rejects negative and overflowing values with ErrNegative
+// and ErrOverflow.
 func (sid *ID) UnmarshalJSON(b []byte) error {
-	if len(b) < 3 || b[0] != '"' || b[len(b)-1] != '"' {
+	if len(b) > 0 && b[0] == '"' {
+		if len(b) < 3 || b[len(b)-1] != '"' {
+			return JSONSyntaxError{b}
+		}
+
+		i, err := ParseBytes(b[1 : len(b)-1])
+		if err != nil {
+			return err
+		}
+
+		*sid = i
+		return nil
+	}
+
+	i, err := ParseBytes(b)
+	if err != nil {
+		if err == ErrNegative || err == ErrOverflow {
+			return err
+		}
 		return JSONSyntaxError{b}
 	}
 
-	i, err := strconv.ParseInt(string(b[1:len(b)-1]), 10, 64)
+	*sid = i
+	return nil
+}
+
+// NumberID is a snowflake ID that marshals to JSON as a bare number instead
+// of a string. Convert an ID to NumberID to opt in for consumers that expect
+// numbers; note that JavaScript cannot represent every ID exactly as a number.
+type NumberID ID
+
+// MarshalJSON returns the snowflake ID as a json number.
+func (n NumberID) MarshalJSON() ([]byte, error) {
+	return ID(n).AppendString(make([]byte, 0, 20)), nil
+}
+
+// UnmarshalJSON converts a json number or string of a snowflake ID into a NumberID.
+func (n *NumberID) UnmarshalJSON(b []byte) error {
+	return (*ID)(n).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the snowflake ID as
+// a decimal string. This lets IDs be used as JSON map keys and in text configs.
+func (sid ID) MarshalText() ([]byte, error) {
+	return sid.Bytes(), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for decimal snowflake IDs
+func (sid *ID) UnmarshalText(b []byte) error {
+	i, err := ParseBytes(b)
 	if err != nil {
 		return err
 	}
+	*sid = i
+	return nil
+}
 
-	*sid = ID(i)
+// MarshalBinary implements encoding.BinaryMarshaler, encoding the snowflake ID
+// as 8 big endian bytes like IntBytes
+func (sid ID) MarshalBinary() ([]byte, error) {
+	b := sid.IntBytes()
+	return b[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for the 8 big endian
+// bytes produced by MarshalBinary. Bytes with the sign bit set give ErrNegative.
+func (sid *ID) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return ErrInvalidBinary
+	}
+	var id [8]byte
+	copy(id[:], b)
+	i := ParseIntBytes(id)
+	if i < 0 {
+		return ErrNegative
+	}
+	*sid = i
 	return nil
 }
 
 // Time returns an int64 unix timestamp in milliseconds of the snowflake ID time
 func (sid ID) Time() int64 {
-	return (int64(sid) >> timestampShift) + epoch
+	return DefaultLayout.Time(sid)
 }
 
-// GetTimestampMax Timestamp maximum
+// GetTimestampMax Timestamp maximum of DefaultLayout.
+// Use Layout.TimestampMax for other layouts.
 func GetTimestampMax() int64 {
-	return timestampMax
+	return DefaultLayout.TimestampMax()
 }
 
-// GetDatacenterIDMax Maximum number of data center id supported.
+// GetDatacenterIDMax Maximum number of data center id supported by DefaultLayout.
+// Use Layout.DatacenterIDMax for other layouts.
 func GetDatacenterIDMax() int64 {
-	return datacenterIDMax
+	return DefaultLayout.DatacenterIDMax()
 }
 
-// GetWorkerIDMax Maximum number of machine id supported
+// GetWorkerIDMax Maximum number of machine id supported by DefaultLayout.
+// Use Layout.WorkerIDMax for other layouts.
 func GetWorkerIDMax() int64 {
-	return workerIDMax
+	return DefaultLayout.WorkerIDMax()
 }
 
-// GetSequenceMask Maximum number of sequence id supported
+// GetSequenceMask Maximum number of sequence id supported by DefaultLayout.
+// Use Layout.SequenceMask for other layouts.
 func GetSequenceMask() int64 {
-	return sequenceMask
+	return DefaultLayout.SequenceMask()
 }
@@ -2,9 +2,17 @@ package snowflake
 
 import (
 	"bytes"
+	"context"
+	"encoding"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"math"
 	"reflect"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewSnowflake(t *testing.T) {
@@ -68,6 +76,38 @@ func TestGetTime(t *testing.T) {
 	t.Logf("time:%v", GetGenTime(val))
 }
 
+func TestGenTime(t *testing.T) {
+	created := time.Date(2021, 3, 14, 23, 41, 24, 123000000, time.UTC)
+	s, err := NewSnowflake(0, 1, WithClock(NewFakeClock(created.Add(456*time.Microsecond))))
+	if err != nil {
+		t.Error(err)
+	}
+	val := s.NextVal()
+
+	gt := val.GenTime()
+	if !gt.Equal(created) || gt.Location() != time.UTC {
+		t.Fatalf("GenTime %s != %s", gt, created)
+	}
+	if gt.UnixNano()/1000000 != val.Time() {
+		t.Fatalf("GenTime %d != Time %d", gt.UnixNano()/1000000, val.Time())
+	}
+
+	tt := []struct {
+		loc      *time.Location
+		expected string
+	}{
+		{nil, "2021-03-14 23:41:24.123 +0000"},
+		{time.UTC, "2021-03-14 23:41:24.123 +0000"},
+		{time.FixedZone("CST", 8*3600), "2021-03-15 07:41:24.123 +0800"},
+		{time.FixedZone("EST", -5*3600), "2021-03-14 18:41:24.123 -0500"},
+	}
+	for _, tc := range tt {
+		if f := val.Format("2006-01-02 15:04:05.000 -0700", tc.loc); f != tc.expected {
+			t.Fatalf("Format %q != %q", f, tc.expected)
+		}
+	}
+}
+
 func TestGetDeviceID(t *testing.T) {
 	s, err := NewSnowflake(28, 11)
 	if err != nil {
@@ -97,6 +137,159 @@ func TestNewNode(t *testing.T) {
 
 }
 
+func TestNext(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	var x ID
+	for i := 0; i < 1000; i++ {
+		y, err := node.Next()
+		if err != nil {
+			t.Fatalf("error generating ID, %s", err)
+		}
+		if y <= x {
+			t.Fatalf("y(%d) <= x(%d)", y, x)
+		}
+		x = y
+	}
+}
+
+func TestNextBatch(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Hour))
+	c.SetStep(time.Millisecond / 4)
+	node, err := NewSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	ids := make([]ID, 3*sequenceMask)
+	n, err := node.NextBatch(ids)
+	if err != nil {
+		t.Fatalf("error generating batch, %s", err)
+	}
+	if n != len(ids) {
+		t.Fatalf("n %d != %d", n, len(ids))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("ids[%d](%d) <= ids[%d](%d)", i, ids[i], i-1, ids[i-1])
+		}
+	}
+	if GetTimestamp(ids[len(ids)-1]) == GetTimestamp(ids[0]) {
+		t.Fatalf("batch did not roll into the next millisecond")
+	}
+
+	next := node.NextVal()
+	if next <= ids[len(ids)-1] {
+		t.Fatalf("next(%d) <= last(%d)", next, ids[len(ids)-1])
+	}
+}
+
+func TestNextN(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	ids, err := node.NextN(10000)
+	if err != nil {
+		t.Fatalf("error generating batch, %s", err)
+	}
+	if len(ids) != 10000 {
+		t.Fatalf("len %d != 10000", len(ids))
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("ids[%d](%d) <= ids[%d](%d)", i, ids[i], i-1, ids[i-1])
+		}
+	}
+
+	if ids, err = node.NextN(0); ids != nil || err != nil {
+		t.Fatalf("expected nil batch, got %v, %v", ids, err)
+	}
+}
+
+func TestNextBatchError(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Duration(timestampMax) * time.Millisecond))
+	c.SetStep(time.Millisecond)
+	node, err := NewSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	ids := make([]ID, 3)
+	n, err := node.NextBatch(ids)
+	if err != ErrEpochExhausted {
+		t.Fatalf("expected ErrEpochExhausted, got %v", err)
+	}
+	if n != 1 || ids[0] == 0 {
+		t.Fatalf("expected one ID before the error, got %d: %v", n, ids)
+	}
+}
+
+// stuckClock is a FakeClock whose timers never fire
+type stuckClock struct{ *FakeClock }
+
+func (stuckClock) After(time.Duration) <-chan time.Time { return nil }
+
+func TestNextContextWaited(t *testing.T) {
+	c := NewFakeClock(epochTime.Add(time.Hour))
+	node, err := NewSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	for i := int64(0); i <= sequenceMask; i++ {
+		_, waited, err := node.NextContext(context.Background())
+		if err != nil {
+			t.Fatalf("error generating ID, %s", err)
+		}
+		if waited != 0 {
+			t.Fatalf("waited %s before the sequence was exhausted", waited)
+		}
+	}
+
+	id, waited, err := node.NextContext(context.Background())
+	if err != nil {
+		t.Fatalf("error generating ID, %s", err)
+	}
+	if waited != time.Millisecond {
+		t.Fatalf("waited %s != %s", waited, time.Millisecond)
+	}
+	if seq := DefaultLayout.Sequence(id); seq != 0 {
+		t.Fatalf("sequence %d != 0", seq)
+	}
+}
+
+func TestNextContextCanceled(t *testing.T) {
+	c := stuckClock{NewFakeClock(epochTime.Add(time.Hour))}
+	node, err := NewSnowflake(1, 1, WithClock(c))
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err = node.NextContext(ctx); !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected ErrContextCanceled, got %v", err)
+	}
+
+	// Exhaust the sequence, the clock never moves so the next call has to
+	// wait until the deadline.
+	for i := int64(0); i <= sequenceMask; i++ {
+		if _, err = node.Next(); err != nil {
+			t.Fatalf("error generating ID, %s", err)
+		}
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err = node.NextContext(ctx); !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrContextCanceled, got %v", err)
+	}
+}
+
 // lazy check if Generate will create duplicate IDs
 // would be good to later enhance this with more smarts
 func TestGenerateDuplicateID(t *testing.T) {
@@ -150,7 +343,10 @@ func TestPrintAll(t *testing.T) {
 	t.Logf("Base32   : %#v", id.Base32())
 	t.Logf("Base36   : %#v", id.Base36())
 	t.Logf("Base58   : %#v", id.Base58())
+	t.Logf("Base62   : %#v", id.Base62())
 	t.Logf("Base64   : %#v", id.Base64())
+	t.Logf("RawBase64: %#v", id.RawBase64())
+	t.Logf("RawURLB64: %#v", id.RawURLBase64())
 	t.Logf("Bytes    : %#v", id.Bytes())
 	t.Logf("IntBytes : %#v", id.IntBytes())
 
@@ -311,6 +507,56 @@ func TestBase58(t *testing.T) {
 	}
 }
 
+func TestBase62(t *testing.T) {
+
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	for i := 0; i < 10; i++ {
+
+		sf := node.NextVal()
+		b62 := sf.Base62()
+		psf, err := ParseBase62([]byte(b62))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sf != psf {
+			t.Fatal("Parsed does not match String.")
+		}
+	}
+
+	tt := []struct {
+		id  ID
+		b62 string
+	}{
+		{0, "0"},
+		{61, "z"},
+		{62, "10"},
+		{13587, "3X9"},
+		{ID(math.MaxInt64), "AzL8n0Y58m7"},
+	}
+	for _, tc := range tt {
+		if b62 := tc.id.Base62(); b62 != tc.b62 {
+			t.Fatalf("Base62 %q != %q", b62, tc.b62)
+		}
+		if psf, err := ParseBase62([]byte(tc.b62)); err != nil || psf != tc.id {
+			t.Fatalf("ParseBase62(%q) %d, %v", tc.b62, psf, err)
+		}
+	}
+
+	if _, err = ParseBase62([]byte("AzL8n0Y58m8")); err != ErrOverflow {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+	if _, err = ParseBase62([]byte("zzzzzzzzzzzzzzzzzzzz")); err != ErrOverflow {
+		t.Fatalf("expected ErrOverflow, got %v", err)
+	}
+	if _, err = ParseBase62([]byte("3X-b")); err != ErrInvalidBase62 {
+		t.Fatalf("expected ErrInvalidBase62, got %v", err)
+	}
+}
+
 func TestBase64(t *testing.T) {
 	node, err := NewSnowflake(1, 1)
 	if err != nil {
@@ -341,6 +587,74 @@ func TestBase64(t *testing.T) {
 	}
 }
 
+func TestRawBase64(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		oID := node.NextVal()
+
+		std := oID.RawBase64()
+		if len(std) != 11 {
+			t.Fatalf("RawBase64 %q is not 11 characters", std)
+		}
+		pID, err := ParseRawBase64(std)
+		if err != nil {
+			t.Fatalf("error parsing, %s", err)
+		}
+		if pID != oID {
+			t.Fatalf("pID %v != oID %v", pID, oID)
+		}
+
+		url := oID.RawURLBase64()
+		pID, err = ParseRawURLBase64(url)
+		if err != nil {
+			t.Fatalf("error parsing, %s", err)
+		}
+		if pID != oID {
+			t.Fatalf("pID %v != oID %v", pID, oID)
+		}
+	}
+
+	id := ID(1116823421972381696) // 0x0f7fc0fc2f800000
+	if s := ID(-1).RawBase64(); s != "//////////8" {
+		t.Fatalf("RawBase64 %q != %q", s, "//////////8")
+	}
+	if s := ID(-1).RawURLBase64(); s != "__________8" {
+		t.Fatalf("RawURLBase64 %q != %q", s, "__________8")
+	}
+	if s := id.RawBase64(); s != "D3/A/C+AAAA" {
+		t.Fatalf("RawBase64 %q != %q", s, "D3/A/C+AAAA")
+	}
+	if s := id.RawURLBase64(); s != "D3_A_C-AAAA" {
+		t.Fatalf("RawURLBase64 %q != %q", s, "D3_A_C-AAAA")
+	}
+
+	for _, ms := range []string{"", "D3/A/C+AAA", "D3/A/C+AAAA=", "D3/A/C+AAAAA", "D3_A_C-AAAA"} {
+		if _, err = ParseRawBase64(ms); err == nil {
+			t.Fatalf("no error parsing %q", ms)
+		}
+	}
+	if _, err = ParseRawURLBase64("D3/A/C+AAAA"); err == nil {
+		t.Fatalf("no error parsing standard base64 as URL-safe")
+	}
+
+	// Only the canonical last character is accepted.
+	if pID, err := ParseRawBase64("AAAAAAAAAAA"); err != nil || pID != 0 {
+		t.Fatalf("ParseRawBase64 canonical = %d, %v", pID, err)
+	}
+	for _, ms := range []string{"AAAAAAAAAAB", "D3/A/C+AAAB"} {
+		if _, err = ParseRawBase64(ms); err == nil {
+			t.Fatalf("no error parsing non-canonical %q", ms)
+		}
+	}
+	if _, err = ParseRawURLBase64("D3_A_C-AAAB"); err == nil {
+		t.Fatalf("no error parsing non-canonical %q", "D3_A_C-AAAB")
+	}
+}
+
 func TestBytes(t *testing.T) {
 	node, err := NewSnowflake(1, 1)
 	if err != nil {
@@ -394,6 +708,72 @@ func TestIntBytes(t *testing.T) {
 
 }
 
+func TestParseErrors(t *testing.T) {
+	parsers := map[string]func(string) (ID, error){
+		"ParseString":       ParseString,
+		"ParseBase2":        ParseBase2,
+		"ParseBase32":       func(s string) (ID, error) { return ParseBase32([]byte(s)) },
+		"ParseBase36":       ParseBase36,
+		"ParseBase58":       func(s string) (ID, error) { return ParseBase58([]byte(s)) },
+		"ParseBase62":       func(s string) (ID, error) { return ParseBase62([]byte(s)) },
+		"ParseBase64":       ParseBase64,
+		"ParseRawBase64":    ParseRawBase64,
+		"ParseRawURLBase64": ParseRawURLBase64,
+		"ParseBytes":        func(s string) (ID, error) { return ParseBytes([]byte(s)) },
+	}
+	for name, parse := range parsers {
+		if _, err := parse(""); err != ErrEmpty {
+			t.Fatalf("%s: expected ErrEmpty, got %v", name, err)
+		}
+	}
+
+	tt := []struct {
+		name   string
+		result parseResult
+		err    error
+	}{
+		{"ParseString", result(ParseString("-13587")), ErrNegative},
+		{"ParseString", result(ParseString("9223372036854775808")), ErrOverflow},
+		{"ParseBase2", result(ParseBase2("-1")), ErrNegative},
+		{"ParseBase2", result(ParseBase2("1" + ID(math.MaxInt64).Base2())), ErrOverflow},
+		{"ParseBase36", result(ParseBase36("-zz")), ErrNegative},
+		{"ParseBase36", result(ParseBase36("1y2p0ij32e8e8")), ErrOverflow},
+		{"ParseBase32", result(ParseBase32([]byte("b" + ID(math.MaxInt64).Base32()))), ErrOverflow},
+		{"ParseBase32", result(ParseBase32([]byte("ybn~"))), ErrInvalidBase32},
+		{"ParseBase58", result(ParseBase58([]byte("2" + ID(math.MaxInt64).Base58()))), ErrOverflow},
+		{"ParseBase58", result(ParseBase58([]byte("123~"))), ErrInvalidBase58},
+		{"ParseBase64", result(ParseBase64(base64.StdEncoding.EncodeToString([]byte("-13587")))), ErrNegative},
+		{"ParseRawBase64", result(ParseRawBase64(ID(-1).RawBase64())), ErrNegative},
+		{"ParseBytes", result(ParseBytes([]byte("-13587"))), ErrNegative},
+	}
+	for _, tc := range tt {
+		if tc.result.err != tc.err {
+			t.Fatalf("%s: expected %v, got %v", tc.name, tc.err, tc.result.err)
+		}
+		if tc.result.id != -1 {
+			t.Fatalf("%s: expected -1 on error, got %d", tc.name, tc.result.id)
+		}
+	}
+
+	// Leading zero digits do not overflow.
+	if id, err := ParseBase32([]byte("yyyy" + ID(math.MaxInt64).Base32())); err != nil || id != math.MaxInt64 {
+		t.Fatalf("ParseBase32 %d, %v", id, err)
+	}
+	if id, err := ParseBase58([]byte("1111" + ID(math.MaxInt64).Base58())); err != nil || id != math.MaxInt64 {
+		t.Fatalf("ParseBase58 %d, %v", id, err)
+	}
+}
+
+// parseResult holds what a parser returned so it can be checked in a table
+type parseResult struct {
+	id  ID
+	err error
+}
+
+func result(id ID, err error) parseResult {
+	return parseResult{id, err}
+}
+
 //******************************************************************************
 // Marshall Test Methods
 
@@ -426,8 +806,17 @@ func TestUnmarshalJSON(t *testing.T) {
 		expectedErr error
 	}{
 		{`"13587"`, 13587, nil},
-		{`1`, 0, JSONSyntaxError{[]byte(`1`)}},
+		{`1`, 1, nil},
+		{`1116766490855473152`, 1116766490855473152, nil},
 		{`"invalid`, 0, JSONSyntaxError{[]byte(`"invalid`)}},
+		{`""`, 0, JSONSyntaxError{[]byte(`""`)}},
+		{`1.5`, 0, JSONSyntaxError{[]byte(`1.5`)}},
+		{`null`, 0, JSONSyntaxError{[]byte(`null`)}},
+		{``, 0, JSONSyntaxError{[]byte(``)}},
+		{`"-5"`, 0, ErrNegative},
+		{`-5`, 0, ErrNegative},
+		{`"9223372036854775808"`, 0, ErrOverflow},
+		{`9223372036854775808`, 0, ErrOverflow},
 	}
 
 	for _, tc := range tt {
@@ -443,6 +832,138 @@ func TestUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestUnmarshalJSONMatchesText(t *testing.T) {
+	// JSON, text and SQL string sources accept and reject the same values.
+	for _, v := range []string{"13587", "0", "-5", "9223372036854775807", "9223372036854775808", "abc", ""} {
+		var jid, qid, tid, sid ID
+		jerr := jid.UnmarshalJSON([]byte(v))
+		qerr := qid.UnmarshalJSON([]byte(`"` + v + `"`))
+		terr := tid.UnmarshalText([]byte(v))
+		serr := sid.Scan(v)
+		if (jerr == nil) != (terr == nil) || (qerr == nil) != (terr == nil) || (serr == nil) != (terr == nil) {
+			t.Fatalf("%q: json %v, quoted json %v, text %v, sql %v", v, jerr, qerr, terr, serr)
+		}
+		if terr == nil && (jid != tid || qid != tid || sid != tid) {
+			t.Fatalf("%q: json %d, quoted json %d, text %d, sql %d", v, jid, qid, tid, sid)
+		}
+	}
+}
+
+func TestNumberID(t *testing.T) {
+	type payload struct {
+		ID  NumberID `json:"id"`
+		Ref ID       `json:"ref"`
+	}
+	p := payload{ID: 1116766490855473152, Ref: 13587}
+
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("error marshaling, %s", err)
+	}
+	expected := `{"id":1116766490855473152,"ref":"13587"}`
+	if string(b) != expected {
+		t.Fatalf("Got %s, expected %s", b, expected)
+	}
+
+	var pp payload
+	if err = json.Unmarshal(b, &pp); err != nil {
+		t.Fatalf("error unmarshaling, %s", err)
+	}
+	if pp != p {
+		t.Fatalf("Got %+v, expected %+v", pp, p)
+	}
+
+	if err = json.Unmarshal([]byte(`{"id":"13587","ref":1116766490855473152}`), &pp); err != nil {
+		t.Fatalf("error unmarshaling, %s", err)
+	}
+	if pp.ID != 13587 || pp.Ref != 1116766490855473152 {
+		t.Fatalf("Got %+v", pp)
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	var _ encoding.TextMarshaler = ID(0)
+	var _ encoding.TextUnmarshaler = new(ID)
+
+	id := ID(13587)
+	b, err := id.MarshalText()
+	if err != nil || string(b) != "13587" {
+		t.Fatalf("MarshalText %q, %v", b, err)
+	}
+
+	var pid ID
+	if err = pid.UnmarshalText(b); err != nil || pid != id {
+		t.Fatalf("UnmarshalText %d, %v", pid, err)
+	}
+	if err = pid.UnmarshalText([]byte("invalid")); err == nil {
+		t.Fatalf("no error unmarshaling invalid text")
+	}
+}
+
+func TestJSONMapKey(t *testing.T) {
+	m := map[ID]string{13587: "a", 1116766490855473152: "b"}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("error marshaling, %s", err)
+	}
+	expected := `{"1116766490855473152":"b","13587":"a"}`
+	if string(b) != expected {
+		t.Fatalf("Got %s, expected %s", b, expected)
+	}
+
+	var pm map[ID]string
+	if err = json.Unmarshal(b, &pm); err != nil {
+		t.Fatalf("error unmarshaling, %s", err)
+	}
+	if !reflect.DeepEqual(pm, m) {
+		t.Fatalf("Got %v, expected %v", pm, m)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	var _ encoding.BinaryMarshaler = ID(0)
+	var _ encoding.BinaryUnmarshaler = new(ID)
+
+	id := ID(13587)
+	b, err := id.MarshalBinary()
+	expected := []byte{0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x35, 0x13}
+	if err != nil || !bytes.Equal(b, expected) {
+		t.Fatalf("MarshalBinary %v, %v", b, err)
+	}
+
+	var pid ID
+	if err = pid.UnmarshalBinary(b); err != nil || pid != id {
+		t.Fatalf("UnmarshalBinary %d, %v", pid, err)
+	}
+	if err = pid.UnmarshalBinary([]byte{0x80, 0, 0, 0, 0, 0, 0, 1}); err != ErrNegative {
+		t.Fatalf("UnmarshalBinary negative error %v != %v", err, ErrNegative)
+	}
+	if err = pid.UnmarshalBinary(b[1:]); err != ErrInvalidBinary {
+		t.Fatalf("expected ErrInvalidBinary, got %v", err)
+	}
+}
+
+func TestGob(t *testing.T) {
+	type entry struct {
+		ID   ID
+		Refs []ID
+	}
+	node, _ := NewSnowflake(1, 1)
+	e := entry{ID: node.NextVal(), Refs: []ID{node.NextVal(), node.NextVal()}}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(e); err != nil {
+		t.Fatalf("error encoding, %s", err)
+	}
+	var pe entry
+	if err := gob.NewDecoder(&buf).Decode(&pe); err != nil {
+		t.Fatalf("error decoding, %s", err)
+	}
+	if !reflect.DeepEqual(pe, e) {
+		t.Fatalf("Got %v, expected %v", pe, e)
+	}
+}
+
 // ****************************************************************************
 // Benchmark Methods
 
@@ -496,6 +1017,31 @@ func BenchmarkBase58(b *testing.B) {
 		sf.Base58()
 	}
 }
+func BenchmarkParseBase62(b *testing.B) {
+
+	node, _ := NewSnowflake(1, 1)
+	sf := node.NextVal()
+	b62 := sf.Base62()
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		ParseBase62([]byte(b62))
+	}
+}
+func BenchmarkBase62(b *testing.B) {
+
+	node, _ := NewSnowflake(1, 1)
+	sf := node.NextVal()
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sf.Base62()
+	}
+}
 func BenchmarkGenerate(b *testing.B) {
 
 	node, _ := NewSnowflake(1, 1)
@@ -508,6 +1054,34 @@ func BenchmarkGenerate(b *testing.B) {
 	}
 }
 
+func BenchmarkGenerateParallel(b *testing.B) {
+
+	node, _ := NewSnowflake(1, 1)
+
+	b.ReportAllocs()
+	b.SetParallelism(64)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = node.NextVal()
+		}
+	})
+}
+
+func BenchmarkGenerateBatch(b *testing.B) {
+
+	node, _ := NewSnowflake(1, 1)
+	ids := make([]ID, 1024)
+
+	b.ReportAllocs()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n += len(ids) {
+		_, _ = node.NextBatch(ids)
+	}
+}
+
 func BenchmarkGenerateMaxSequence(b *testing.B) {
 
 	node, _ := NewSnowflake(1, 1)
@@ -0,0 +1,106 @@
+package snowflake
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// The sortable encodings below are fixed width and zero-padded, and their
+// alphabets are in ASCII order, so comparing two encoded strings byte by byte
+// gives the same result as comparing the IDs. This holds for every
+// non-negative ID, which includes every ID a Snowflake generates. Negative
+// IDs are encoded as their unsigned 64-bit value, which the parsers reject.
+
+const (
+	sortableBase32Width = 13 // 13 * 5 bits cover 64 bits
+	sortableBase62Width = 11 // 62^11 > 2^64
+	paddedStringWidth   = 20 // Number of digits of the largest uint64
+)
+
+// ErrInvalidLength is returned by the fixed width parsers when given a value
+// of the wrong length
+var ErrInvalidLength = errors.New("invalid snowflake ID length")
+
+// SortableBase32 returns a 13 character, zero-padded Crockford Base32 string
+// of the snowflake ID that sorts in the same order as the ID
+func (sid ID) SortableBase32() string {
+	var b [sortableBase32Width]byte
+	u := uint64(sid)
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = encodeCrockfordMap[u&31]
+		u >>= 5
+	}
+	return string(b[:])
+}
+
+// ParseSortableBase32 parses a string produced by SortableBase32 into a snowflake ID
+func ParseSortableBase32(id string) (ID, error) {
+	if len(id) == 0 {
+		return -1, ErrEmpty
+	}
+	if len(id) != sortableBase32Width {
+		return -1, ErrInvalidLength
+	}
+
+	var u uint64
+
+	for i := 0; i < len(id); i++ {
+		d := decodeCrockfordMap[id[i]]
+		if d == 0xFF {
+			return -1, ErrInvalidBase32
+		}
+		if u>>59 != 0 {
+			return -1, ErrOverflow
+		}
+		u = u<<5 | uint64(d)
+	}
+
+	if u > math.MaxInt64 {
+		return -1, ErrOverflow
+	}
+	return ID(u), nil
+}
+
+// SortableBase62 returns an 11 character, zero-padded Base62 string of the
+// snowflake ID that sorts in the same order as the ID
+func (sid ID) SortableBase62() string {
+	return sortableBase62Encoding.Encode(sid)
+}
+
+// ParseSortableBase62 parses a string produced by SortableBase62 into a snowflake ID
+func ParseSortableBase62(id string) (ID, error) {
+	return sortableBase62Encoding.DecodeString(id)
+}
+
+// PaddedString returns a 20 digit, zero-padded decimal string of the
+// snowflake ID that sorts in the same order as the ID
+func (sid ID) PaddedString() string {
+	d := strconv.FormatUint(uint64(sid), 10)
+	return strings.Repeat("0", paddedStringWidth-len(d)) + d
+}
+
+// ParsePaddedString parses a string produced by PaddedString into a snowflake ID
+func ParsePaddedString(id string) (ID, error) {
+	if len(id) == 0 {
+		return -1, ErrEmpty
+	}
+	if len(id) != paddedStringWidth {
+		return -1, ErrInvalidLength
+	}
+	if id[0] == '-' {
+		return -1, ErrNegative
+	}
+	u, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+			return -1, ErrOverflow
+		}
+		return -1, err
+	}
+	if u > math.MaxInt64 {
+		return -1, ErrOverflow
+	}
+	return ID(u), nil
+}
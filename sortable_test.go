@@ -0,0 +1,130 @@
+package snowflake
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var sortableEncodings = []struct {
+	name   string
+	width  int
+	encode func(ID) string
+	parse  func(string) (ID, error)
+}{
+	{"SortableBase32", 13, ID.SortableBase32, ParseSortableBase32},
+	{"SortableBase62", 11, ID.SortableBase62, ParseSortableBase62},
+	{"PaddedString", 20, ID.PaddedString, ParsePaddedString},
+}
+
+func TestSortableRoundTrip(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+	ids := []ID{0, 1, 31, 62, 13587, math.MaxInt64, node.NextVal()}
+
+	for _, enc := range sortableEncodings {
+		for _, id := range ids {
+			s := enc.encode(id)
+			if len(s) != enc.width {
+				t.Fatalf("%s(%d) %q is not %d characters", enc.name, id, s, enc.width)
+			}
+			pid, err := enc.parse(s)
+			if err != nil {
+				t.Fatalf("%s: error parsing %q, %s", enc.name, s, err)
+			}
+			if pid != id {
+				t.Fatalf("%s: pID %d != oID %d", enc.name, pid, id)
+			}
+		}
+	}
+}
+
+func TestSortableValues(t *testing.T) {
+	tt := []struct {
+		id       ID
+		expected []string
+	}{
+		{0, []string{"0000000000000", "00000000000", "00000000000000000000"}},
+		{13587, []string{"0000000000D8K", "000000003X9", "00000000000000013587"}},
+		{math.MaxInt64, []string{"7ZZZZZZZZZZZZ", "AzL8n0Y58m7", "09223372036854775807"}},
+	}
+	for _, tc := range tt {
+		for i, enc := range sortableEncodings {
+			if s := enc.encode(tc.id); s != tc.expected[i] {
+				t.Fatalf("%s(%d) %q != %q", enc.name, tc.id, s, tc.expected[i])
+			}
+		}
+	}
+}
+
+func TestSortableOrder(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	ids := make([]ID, 1000)
+	for i := range ids {
+		// Mix small and large IDs to catch missing padding.
+		ids[i] = ID(r.Int63() >> uint(r.Intn(63)))
+	}
+
+	for _, enc := range sortableEncodings {
+		strs := make([]string, len(ids))
+		for i, id := range ids {
+			strs[i] = enc.encode(id)
+		}
+		sort.Strings(strs)
+
+		sorted := append([]ID(nil), ids...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+		for i, s := range strs {
+			id, err := enc.parse(s)
+			if err != nil {
+				t.Fatalf("%s: error parsing %q, %s", enc.name, s, err)
+			}
+			if id != sorted[i] {
+				t.Fatalf("%s: position %d holds %d, expected %d", enc.name, i, id, sorted[i])
+			}
+		}
+	}
+}
+
+func TestSortableErrors(t *testing.T) {
+	tt := []struct {
+		parse func(string) (ID, error)
+		id    string
+		err   error
+	}{
+		{ParseSortableBase32, "D8K", ErrInvalidLength},
+		{ParseSortableBase32, "0000000000D8U", ErrInvalidBase32},
+		{ParseSortableBase32, "G000000000000", ErrOverflow},
+		{ParseSortableBase62, "3X9", ErrInvalidLength},
+		{ParseSortableBase62, "000000003X-", ErrInvalidBase62},
+		{ParseSortableBase62, "zzzzzzzzzzz", ErrOverflow},
+		{ParsePaddedString, "13587", ErrInvalidLength},
+	}
+	for _, tc := range tt {
+		if _, err := tc.parse(tc.id); err != tc.err {
+			t.Fatalf("parsing %q: expected %v, got %v", tc.id, tc.err, err)
+		}
+	}
+
+	for _, ms := range []string{"+0000000000000013587", "-0000000000000013587", "99999999999999999999", "0000000000000001358x"} {
+		if _, err := ParsePaddedString(ms); err == nil {
+			t.Fatalf("no error parsing %q", ms)
+		}
+	}
+
+	// Negative IDs encode to values above math.MaxInt64.
+	for _, enc := range sortableEncodings {
+		for _, id := range []ID{-1, math.MinInt64} {
+			if _, err := enc.parse(enc.encode(id)); err != ErrOverflow {
+				t.Fatalf("%s(%d): expected ErrOverflow, got %v", enc.name, id, err)
+			}
+		}
+		if _, err := enc.parse(""); err != ErrEmpty {
+			t.Fatalf("%s: expected ErrEmpty, got %v", enc.name, err)
+		}
+	}
+}
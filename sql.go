@@ -0,0 +1,93 @@
+package snowflake
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements the sql.Scanner interface so an ID can be read from a
+// BIGINT column. It accepts int64, []byte and string sources; negative
+// values give ErrNegative.
+func (sid *ID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case int64:
+		if v < 0 {
+			return ErrNegative
+		}
+		*sid = ID(v)
+		return nil
+	case []byte:
+		i, err := ParseBytes(v)
+		if err != nil {
+			return err
+		}
+		*sid = i
+		return nil
+	case string:
+		i, err := ParseString(v)
+		if err != nil {
+			return err
+		}
+		*sid = i
+		return nil
+	case nil:
+		return fmt.Errorf("cannot scan NULL into snowflake ID, use NullID")
+	}
+	return fmt.Errorf("cannot scan %T into snowflake ID", src)
+}
+
+// Value implements the driver.Valuer interface, storing the ID as an int64
+func (sid ID) Value() (driver.Value, error) {
+	return int64(sid), nil
+}
+
+// NullID represents a snowflake ID that may be null.
+// It implements the sql.Scanner and driver.Valuer interfaces.
+type NullID struct {
+	ID    ID
+	Valid bool // Valid is true if ID is not NULL
+}
+
+// Scan implements the sql.Scanner interface
+func (n *NullID) Scan(src interface{}) error {
+	if src == nil {
+		n.ID, n.Valid = 0, false
+		return nil
+	}
+	if err := n.ID.Scan(src); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
+
+// Value implements the driver.Valuer interface
+func (n NullID) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	return int64(n.ID), nil
+}
+
+// MarshalJSON returns the snowflake ID as a json string, or null if it is not valid.
+func (n NullID) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+	return n.ID.MarshalJSON()
+}
+
+// UnmarshalJSON converts null, a json string or a json number into a NullID.
+func (n *NullID) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		n.ID, n.Valid = 0, false
+		return nil
+	}
+	if err := n.ID.UnmarshalJSON(b); err != nil {
+		n.Valid = false
+		return err
+	}
+	n.Valid = true
+	return nil
+}
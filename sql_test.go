@@ -0,0 +1,242 @@
+package snowflake
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// stubDriver is an in-memory database/sql driver with a single one-column
+// table per DSN. Every Exec appends its argument as a row and every Query
+// returns all rows.
+type stubDriver struct {
+	mu     sync.Mutex
+	tables map[string][]driver.Value
+}
+
+var stub = &stubDriver{tables: make(map[string][]driver.Value)}
+
+func init() {
+	sql.Register("snowflake-stub", stub)
+}
+
+func (d *stubDriver) Open(name string) (driver.Conn, error) {
+	return &stubConn{d: d, name: name}, nil
+}
+
+type stubConn struct {
+	d    *stubDriver
+	name string
+}
+
+func (c *stubConn) Prepare(query string) (driver.Stmt, error) { return &stubStmt{c}, nil }
+func (c *stubConn) Close() error                              { return nil }
+func (c *stubConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type stubStmt struct{ c *stubConn }
+
+func (s *stubStmt) Close() error  { return nil }
+func (s *stubStmt) NumInput() int { return -1 }
+
+func (s *stubStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.d.mu.Lock()
+	defer s.c.d.mu.Unlock()
+	s.c.d.tables[s.c.name] = append(s.c.d.tables[s.c.name], args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stubStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.c.d.mu.Lock()
+	defer s.c.d.mu.Unlock()
+	rows := append([]driver.Value(nil), s.c.d.tables[s.c.name]...)
+	return &stubRows{rows: rows}, nil
+}
+
+type stubRows struct {
+	rows []driver.Value
+	i    int
+}
+
+func (r *stubRows) Columns() []string { return []string{"id"} }
+func (r *stubRows) Close() error      { return nil }
+
+func (r *stubRows) Next(dest []driver.Value) error {
+	if r.i >= len(r.rows) {
+		return io.EOF
+	}
+	dest[0] = r.rows[r.i]
+	r.i++
+	return nil
+}
+
+// openStub opens an empty table named after the test, which is dropped when
+// the test ends so repeated runs start afresh.
+func openStub(t *testing.T) *sql.DB {
+	stub.mu.Lock()
+	delete(stub.tables, t.Name())
+	stub.mu.Unlock()
+	t.Cleanup(func() {
+		stub.mu.Lock()
+		delete(stub.tables, t.Name())
+		stub.mu.Unlock()
+	})
+
+	db, err := sql.Open("snowflake-stub", t.Name())
+	if err != nil {
+		t.Fatalf("error opening stub database, %s", err)
+	}
+	return db
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	node, err := NewSnowflake(1, 1)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+	db := openStub(t)
+	defer db.Close()
+
+	ids := make([]ID, 5)
+	for i := range ids {
+		ids[i] = node.NextVal()
+		if _, err = db.Exec("INSERT", ids[i]); err != nil {
+			t.Fatalf("error inserting, %s", err)
+		}
+	}
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error querying, %s", err)
+	}
+	defer rows.Close()
+	var i int
+	for ; rows.Next(); i++ {
+		var id ID
+		if err = rows.Scan(&id); err != nil {
+			t.Fatalf("error scanning, %s", err)
+		}
+		if i >= len(ids) {
+			t.Fatalf("scanned more than %d rows", len(ids))
+		}
+		if id != ids[i] {
+			t.Fatalf("id %d != %d", id, ids[i])
+		}
+	}
+	if i != len(ids) {
+		t.Fatalf("scanned %d rows, expected %d", i, len(ids))
+	}
+}
+
+func TestSQLNullID(t *testing.T) {
+	db := openStub(t)
+	defer db.Close()
+
+	values := []interface{}{
+		NullID{ID: 13587, Valid: true},
+		NullID{},
+		"13587",
+		[]byte("13587"),
+		nil,
+	}
+	for _, v := range values {
+		if _, err := db.Exec("INSERT", v); err != nil {
+			t.Fatalf("error inserting %#v, %s", v, err)
+		}
+	}
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error querying, %s", err)
+	}
+	defer rows.Close()
+	expected := []NullID{{13587, true}, {}, {13587, true}, {13587, true}, {}}
+	var i int
+	for ; rows.Next(); i++ {
+		var n NullID
+		if err = rows.Scan(&n); err != nil {
+			t.Fatalf("error scanning, %s", err)
+		}
+		if i >= len(expected) {
+			t.Fatalf("scanned more than %d rows", len(expected))
+		}
+		if n != expected[i] {
+			t.Fatalf("row %d: %+v != %+v", i, n, expected[i])
+		}
+	}
+	if i != len(expected) {
+		t.Fatalf("scanned %d rows, expected %d", i, len(expected))
+	}
+}
+
+func TestScan(t *testing.T) {
+	tt := []struct {
+		src      interface{}
+		expected ID
+		err      bool
+	}{
+		{int64(13587), 13587, false},
+		{int64(-1), 0, true},
+		{[]byte("13587"), 13587, false},
+		{"13587", 13587, false},
+		{"invalid", 0, true},
+		{[]byte{0xFF}, 0, true},
+		{nil, 0, true},
+		{3.14, 0, true},
+	}
+	for _, tc := range tt {
+		var id ID
+		err := id.Scan(tc.src)
+		if tc.err != (err != nil) {
+			t.Fatalf("Scan(%#v) error %v", tc.src, err)
+		}
+		if id != tc.expected {
+			t.Fatalf("Scan(%#v) %d != %d", tc.src, id, tc.expected)
+		}
+	}
+
+	v, err := ID(13587).Value()
+	if err != nil || v != int64(13587) {
+		t.Fatalf("Value %#v, %v", v, err)
+	}
+	v, err = NullID{}.Value()
+	if err != nil || v != nil {
+		t.Fatalf("Value %#v, %v", v, err)
+	}
+}
+
+func TestNullIDJSON(t *testing.T) {
+	tt := []struct {
+		json     string
+		expected NullID
+	}{
+		{`null`, NullID{}},
+		{`"13587"`, NullID{13587, true}},
+		{`13587`, NullID{13587, true}},
+	}
+	for _, tc := range tt {
+		n := NullID{ID: 1, Valid: true}
+		if err := json.Unmarshal([]byte(tc.json), &n); err != nil {
+			t.Fatalf("error unmarshaling %s, %s", tc.json, err)
+		}
+		if n != tc.expected {
+			t.Fatalf("Got %+v, expected %+v", n, tc.expected)
+		}
+	}
+
+	var n NullID
+	if err := json.Unmarshal([]byte(`"invalid"`), &n); err == nil || n.Valid {
+		t.Fatalf("expected an invalid NullID, got %+v, %v", n, err)
+	}
+
+	b, err := json.Marshal([]NullID{{13587, true}, {}})
+	if err != nil {
+		t.Fatalf("error marshaling, %s", err)
+	}
+	if string(b) != `["13587",null]` {
+		t.Fatalf("Got %s, expected %s", b, `["13587",null]`)
+	}
+}
@@ -0,0 +1,297 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+)
+
+// A TypedID is a snowflake ID of the entity type T, such as TypedID[User].
+// IDs of different entity types are distinct Go types, so passing the ID of
+// one entity where another is expected does not compile. T is only a marker
+// and is never instantiated; an empty struct type is enough.
+//
+// A TypedID has the same methods and encodings as ID. Use ID to get the plain
+// ID back.
+type TypedID[T any] ID
+
+// A Generator mints snowflake IDs. Both Snowflake and AtomicSnowflake are
+// Generators.
+type Generator interface {
+	NextVal() ID
+	Next() (ID, error)
+	NextContext(ctx context.Context) (ID, time.Duration, error)
+}
+
+// A TypedGenerator mints TypedIDs of the entity type T from a Generator.
+// Generators may be shared by TypedGenerators of different entity types.
+type TypedGenerator[T any] struct {
+	g Generator
+}
+
+// NewTypedGenerator returns a TypedGenerator that mints TypedIDs from g
+func NewTypedGenerator[T any](g Generator) *TypedGenerator[T] {
+	return &TypedGenerator[T]{g: g}
+}
+
+// NextVal returns the next TypedID, or 0 when an ID cannot be generated
+func (g *TypedGenerator[T]) NextVal() TypedID[T] {
+	return TypedID[T](g.g.NextVal())
+}
+
+// Next returns the next TypedID, or an error when it cannot be generated
+func (g *TypedGenerator[T]) Next() (TypedID[T], error) {
+	sid, err := g.g.Next()
+	return TypedID[T](sid), err
+}
+
+// NextContext is like Next but waits for the next millisecond while ctx is
+// active when the sequence is exhausted, and reports how long it waited
+func (g *TypedGenerator[T]) NextContext(ctx context.Context) (TypedID[T], time.Duration, error) {
+	sid, waited, err := g.g.NextContext(ctx)
+	return TypedID[T](sid), waited, err
+}
+
+// ParseTyped converts the result of any of the ID parsers into a TypedID,
+// for example ParseTyped[User](ParseBase58(b))
+func ParseTyped[T any](sid ID, err error) (TypedID[T], error) {
+	if err != nil {
+		return -1, err
+	}
+	return TypedID[T](sid), nil
+}
+
+// ID returns the plain snowflake ID
+func (sid TypedID[T]) ID() ID {
+	return ID(sid)
+}
+
+// Int64 returns an int64 of the snowflake ID
+func (sid TypedID[T]) Int64() int64 {
+	return int64(sid)
+}
+
+// String returns a string of the snowflake ID
+func (sid TypedID[T]) String() string {
+	return ID(sid).String()
+}
+
+// Base2 returns a string base2 of the snowflake ID
+func (sid TypedID[T]) Base2() string {
+	return ID(sid).Base2()
+}
+
+// Base32 returns the z-base-32 string of the snowflake ID
+func (sid TypedID[T]) Base32() string {
+	return ID(sid).Base32()
+}
+
+// Base36 returns a base36 string of the snowflake ID
+func (sid TypedID[T]) Base36() string {
+	return ID(sid).Base36()
+}
+
+// Base58 returns a base58 string of the snowflake ID
+func (sid TypedID[T]) Base58() string {
+	return ID(sid).Base58()
+}
+
+// Base62 returns a base62 string of the snowflake ID
+func (sid TypedID[T]) Base62() string {
+	return ID(sid).Base62()
+}
+
+// Base64 returns a base64 string of the decimal snowflake ID
+func (sid TypedID[T]) Base64() string {
+	return ID(sid).Base64()
+}
+
+// RawBase64 returns the unpadded base64 string of the 8 byte snowflake ID
+func (sid TypedID[T]) RawBase64() string {
+	return ID(sid).RawBase64()
+}
+
+// RawURLBase64 returns the unpadded URL-safe base64 string of the 8 byte
+// snowflake ID
+func (sid TypedID[T]) RawURLBase64() string {
+	return ID(sid).RawURLBase64()
+}
+
+// CrockfordBase32 returns the Crockford Base32 string of the snowflake ID
+func (sid TypedID[T]) CrockfordBase32() string {
+	return ID(sid).CrockfordBase32()
+}
+
+// CrockfordBase32Check returns the Crockford Base32 string of the snowflake
+// ID followed by its check symbol
+func (sid TypedID[T]) CrockfordBase32Check() string {
+	return ID(sid).CrockfordBase32Check()
+}
+
+// SortableBase32 returns the fixed width Crockford Base32 string of the
+// snowflake ID
+func (sid TypedID[T]) SortableBase32() string {
+	return ID(sid).SortableBase32()
+}
+
+// SortableBase62 returns the fixed width Base62 string of the snowflake ID
+func (sid TypedID[T]) SortableBase62() string {
+	return ID(sid).SortableBase62()
+}
+
+// PaddedString returns the fixed width decimal string of the snowflake ID
+func (sid TypedID[T]) PaddedString() string {
+	return ID(sid).PaddedString()
+}
+
+// Bytes returns a byte slice of the snowflake ID
+func (sid TypedID[T]) Bytes() []byte {
+	return ID(sid).Bytes()
+}
+
+// IntBytes returns an array of bytes of the snowflake ID, encoded as a
+// big endian integer
+func (sid TypedID[T]) IntBytes() [8]byte {
+	return ID(sid).IntBytes()
+}
+
+// AppendString appends the decimal string of the snowflake ID to dst
+func (sid TypedID[T]) AppendString(dst []byte) []byte {
+	return ID(sid).AppendString(dst)
+}
+
+// AppendBase32 appends the z-base-32 string of the snowflake ID to dst
+func (sid TypedID[T]) AppendBase32(dst []byte) []byte {
+	return ID(sid).AppendBase32(dst)
+}
+
+// AppendBase58 appends the base58 string of the snowflake ID to dst
+func (sid TypedID[T]) AppendBase58(dst []byte) []byte {
+	return ID(sid).AppendBase58(dst)
+}
+
+// AppendBase62 appends the base62 string of the snowflake ID to dst
+func (sid TypedID[T]) AppendBase62(dst []byte) []byte {
+	return ID(sid).AppendBase62(dst)
+}
+
+// AppendBase64 appends the base64 string of the decimal snowflake ID to dst
+func (sid TypedID[T]) AppendBase64(dst []byte) []byte {
+	return ID(sid).AppendBase64(dst)
+}
+
+// AppendRawBase64 appends the unpadded base64 string of the 8 byte snowflake
+// ID to dst
+func (sid TypedID[T]) AppendRawBase64(dst []byte) []byte {
+	return ID(sid).AppendRawBase64(dst)
+}
+
+// AppendRawURLBase64 appends the unpadded URL-safe base64 string of the 8 byte
+// snowflake ID to dst
+func (sid TypedID[T]) AppendRawURLBase64(dst []byte) []byte {
+	return ID(sid).AppendRawURLBase64(dst)
+}
+
+// Time returns the milliseconds since the Unix epoch at which the snowflake
+// ID was generated
+func (sid TypedID[T]) Time() int64 {
+	return ID(sid).Time()
+}
+
+// GenTime returns the time in UTC at which the snowflake ID was generated
+func (sid TypedID[T]) GenTime() time.Time {
+	return ID(sid).GenTime()
+}
+
+// Format formats the time at which the snowflake ID was generated
+func (sid TypedID[T]) Format(layout string, loc *time.Location) string {
+	return ID(sid).Format(layout, loc)
+}
+
+// Parts decomposes the snowflake ID with the default layout
+func (sid TypedID[T]) Parts() Parts {
+	return ID(sid).Parts()
+}
+
+// MarshalJSON returns a json byte array string of the snowflake ID
+func (sid TypedID[T]) MarshalJSON() ([]byte, error) {
+	return ID(sid).MarshalJSON()
+}
+
+// UnmarshalJSON converts a json byte array of a snowflake ID into a TypedID
+func (sid *TypedID[T]) UnmarshalJSON(b []byte) error {
+	return (*ID)(sid).UnmarshalJSON(b)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (sid TypedID[T]) MarshalText() ([]byte, error) {
+	return ID(sid).MarshalText()
+}
+
+// AppendText implements encoding.TextAppender
+func (sid TypedID[T]) AppendText(b []byte) ([]byte, error) {
+	return ID(sid).AppendText(b)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (sid *TypedID[T]) UnmarshalText(b []byte) error {
+	return (*ID)(sid).UnmarshalText(b)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler
+func (sid TypedID[T]) MarshalBinary() ([]byte, error) {
+	return ID(sid).MarshalBinary()
+}
+
+// AppendBinary implements encoding.BinaryAppender
+func (sid TypedID[T]) AppendBinary(b []byte) ([]byte, error) {
+	return ID(sid).AppendBinary(b)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler
+func (sid *TypedID[T]) UnmarshalBinary(b []byte) error {
+	return (*ID)(sid).UnmarshalBinary(b)
+}
+
+// Scan implements sql.Scanner
+func (sid *TypedID[T]) Scan(src any) error {
+	return (*ID)(sid).Scan(src)
+}
+
+// Value implements driver.Valuer
+func (sid TypedID[T]) Value() (driver.Value, error) {
+	return ID(sid).Value()
+}
+
+// NullTypedID is a TypedID that may be null, for nullable BIGINT columns and
+// optional JSON fields, like NullID
+type NullTypedID[T any] struct {
+	ID    TypedID[T]
+	Valid bool // Valid is true if ID is not NULL
+}
+
+// Scan implements the sql.Scanner interface
+func (n *NullTypedID[T]) Scan(src any) error {
+	var v NullID
+	err := v.Scan(src)
+	n.ID, n.Valid = TypedID[T](v.ID), v.Valid
+	return err
+}
+
+// Value implements the driver.Valuer interface
+func (n NullTypedID[T]) Value() (driver.Value, error) {
+	return NullID{ID: ID(n.ID), Valid: n.Valid}.Value()
+}
+
+// MarshalJSON returns the snowflake ID as a json string, or null if it is not valid.
+func (n NullTypedID[T]) MarshalJSON() ([]byte, error) {
+	return NullID{ID: ID(n.ID), Valid: n.Valid}.MarshalJSON()
+}
+
+// UnmarshalJSON converts null, a json string or a json number into a NullTypedID.
+func (n *NullTypedID[T]) UnmarshalJSON(b []byte) error {
+	var v NullID
+	err := v.UnmarshalJSON(b)
+	n.ID, n.Valid = TypedID[T](v.ID), v.Valid
+	return err
+}
@@ -0,0 +1,265 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type (
+	testUser  struct{}
+	testOrder struct{}
+)
+
+var (
+	_ json.Marshaler             = TypedID[testUser](0)
+	_ json.Unmarshaler           = (*TypedID[testUser])(nil)
+	_ encoding.TextMarshaler     = TypedID[testUser](0)
+	_ encoding.TextUnmarshaler   = (*TypedID[testUser])(nil)
+	_ encoding.BinaryMarshaler   = TypedID[testUser](0)
+	_ encoding.BinaryUnmarshaler = (*TypedID[testUser])(nil)
+	_ sql.Scanner                = (*TypedID[testUser])(nil)
+	_ driver.Valuer              = TypedID[testUser](0)
+	_ sql.Scanner                = (*NullTypedID[testUser])(nil)
+	_ driver.Valuer              = NullTypedID[testUser]{}
+	_ json.Marshaler             = NullTypedID[testUser]{}
+	_ json.Unmarshaler           = (*NullTypedID[testUser])(nil)
+	_ Generator                  = (*Snowflake)(nil)
+	_ Generator                  = (*AtomicSnowflake)(nil)
+)
+
+func TestTypedGenerator(t *testing.T) {
+	node, err := NewSnowflake(1, 2)
+	if err != nil {
+		t.Fatalf("error creating NewNode, %s", err)
+	}
+	users := NewTypedGenerator[testUser](node)
+	orders := NewTypedGenerator[testOrder](node)
+
+	u, err := users.Next()
+	if err != nil {
+		t.Fatalf("error generating user ID, %s", err)
+	}
+	o, _, err := orders.NextContext(context.Background())
+	if err != nil {
+		t.Fatalf("error generating order ID, %s", err)
+	}
+	if o.ID() <= u.ID() {
+		t.Fatalf("order ID %d not after user ID %d", o, u)
+	}
+	if v := users.NextVal(); v.ID() <= o.ID() {
+		t.Fatalf("user ID %d not after order ID %d", v, o)
+	}
+
+	if reflect.TypeOf(u) == reflect.TypeOf(o) {
+		t.Fatalf("TypedID[testUser] and TypedID[testOrder] are the same type")
+	}
+
+	p := u.Parts()
+	if p.DatacenterID != 1 || p.WorkerID != 2 {
+		t.Fatalf("Parts %+v", p)
+	}
+
+	atomic, err := NewAtomicSnowflake(1, 2)
+	if err != nil {
+		t.Fatalf("error creating NewAtomicSnowflake, %s", err)
+	}
+	if v, err := NewTypedGenerator[testUser](atomic).Next(); err != nil || v == 0 {
+		t.Fatalf("atomic Next = %d, %v", v, err)
+	}
+}
+
+func TestTypedIDEncodings(t *testing.T) {
+	id := ID(1427970479175499776)
+	tid := TypedID[testUser](id)
+
+	encodings := []struct {
+		name        string
+		typed, want string
+	}{
+		{"String", tid.String(), id.String()},
+		{"Base2", tid.Base2(), id.Base2()},
+		{"Base32", tid.Base32(), id.Base32()},
+		{"Base36", tid.Base36(), id.Base36()},
+		{"Base58", tid.Base58(), id.Base58()},
+		{"Base62", tid.Base62(), id.Base62()},
+		{"Base64", tid.Base64(), id.Base64()},
+		{"RawBase64", tid.RawBase64(), id.RawBase64()},
+		{"RawURLBase64", tid.RawURLBase64(), id.RawURLBase64()},
+		{"CrockfordBase32", tid.CrockfordBase32(), id.CrockfordBase32()},
+		{"CrockfordBase32Check", tid.CrockfordBase32Check(), id.CrockfordBase32Check()},
+		{"SortableBase32", tid.SortableBase32(), id.SortableBase32()},
+		{"SortableBase62", tid.SortableBase62(), id.SortableBase62()},
+		{"PaddedString", tid.PaddedString(), id.PaddedString()},
+		{"Bytes", string(tid.Bytes()), string(id.Bytes())},
+		{"AppendString", string(tid.AppendString(nil)), id.String()},
+		{"AppendBase32", string(tid.AppendBase32(nil)), id.Base32()},
+		{"AppendBase58", string(tid.AppendBase58(nil)), id.Base58()},
+		{"AppendBase62", string(tid.AppendBase62(nil)), id.Base62()},
+		{"Format", tid.Format("2006-01-02", nil), id.Format("2006-01-02", nil)},
+	}
+	for _, e := range encodings {
+		if e.typed != e.want {
+			t.Errorf("%s %s != %s", e.name, e.typed, e.want)
+		}
+	}
+
+	if tid.Int64() != id.Int64() || tid.IntBytes() != id.IntBytes() || tid.Time() != id.Time() || !tid.GenTime().Equal(id.GenTime()) {
+		t.Fatalf("TypedID accessors differ from ID")
+	}
+}
+
+func TestTypedIDMarshal(t *testing.T) {
+	tid := TypedID[testUser](13587)
+
+	b, err := json.Marshal(map[TypedID[testUser]]TypedID[testUser]{tid: tid})
+	if err != nil {
+		t.Fatalf("error marshaling JSON, %s", err)
+	}
+	if string(b) != `{"13587":"13587"}` {
+		t.Fatalf("json %s", b)
+	}
+	var m map[TypedID[testUser]]TypedID[testUser]
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("error unmarshaling JSON, %s", err)
+	}
+	if m[tid] != tid {
+		t.Fatalf("unmarshaled %v", m)
+	}
+
+	bin, err := tid.MarshalBinary()
+	if err != nil {
+		t.Fatalf("error marshaling binary, %s", err)
+	}
+	var got TypedID[testUser]
+	if err := got.UnmarshalBinary(bin); err != nil || got != tid {
+		t.Fatalf("UnmarshalBinary = %d, %v", got, err)
+	}
+	if app, _ := tid.AppendBinary(nil); string(app) != string(bin) {
+		t.Fatalf("AppendBinary %x != %x", app, bin)
+	}
+	if txt, _ := tid.AppendText(nil); string(txt) != "13587" {
+		t.Fatalf("AppendText %s", txt)
+	}
+
+	v, err := tid.Value()
+	if err != nil || v != int64(13587) {
+		t.Fatalf("Value = %v, %v", v, err)
+	}
+	got = 0
+	if err := got.Scan("13587"); err != nil || got != tid {
+		t.Fatalf("Scan = %d, %v", got, err)
+	}
+	if err := got.Scan(nil); err == nil {
+		t.Fatalf("scanning NULL succeeded")
+	}
+}
+
+func TestParseTyped(t *testing.T) {
+	tid, err := ParseTyped[testUser](ParseBase58([]byte("52m")))
+	if err != nil {
+		t.Fatalf("error parsing, %s", err)
+	}
+	if want, _ := ParseBase58([]byte("52m")); tid.ID() != want {
+		t.Fatalf("ParseTyped %d != %d", tid, want)
+	}
+
+	if _, err := ParseTyped[testUser](ParseString("")); err != ErrEmpty {
+		t.Fatalf("error %v != %v", err, ErrEmpty)
+	}
+}
+
+func TestTypedIDMethodSet(t *testing.T) {
+	// Every method of ID must be forwarded by TypedID with the same signature,
+	// apart from the receiver.
+	pairs := []struct{ id, typed reflect.Type }{
+		{reflect.TypeOf(ID(0)), reflect.TypeOf(TypedID[testUser](0))},
+		{reflect.TypeOf((*ID)(nil)), reflect.TypeOf((*TypedID[testUser])(nil))},
+	}
+	for _, p := range pairs {
+		for i := 0; i < p.id.NumMethod(); i++ {
+			m := p.id.Method(i)
+			tm, ok := p.typed.MethodByName(m.Name)
+			if !ok {
+				t.Errorf("%s has no method %s", p.typed, m.Name)
+				continue
+			}
+			if m.Type.NumIn() != tm.Type.NumIn() || m.Type.NumOut() != tm.Type.NumOut() {
+				t.Errorf("%s.%s signature %s != %s", p.typed, m.Name, tm.Type, m.Type)
+				continue
+			}
+			for j := 1; j < m.Type.NumIn(); j++ {
+				if m.Type.In(j) != tm.Type.In(j) {
+					t.Errorf("%s.%s signature %s != %s", p.typed, m.Name, tm.Type, m.Type)
+				}
+			}
+			for j := 0; j < m.Type.NumOut(); j++ {
+				if m.Type.Out(j) != tm.Type.Out(j) {
+					t.Errorf("%s.%s signature %s != %s", p.typed, m.Name, tm.Type, m.Type)
+				}
+			}
+		}
+	}
+}
+
+func TestNullTypedID(t *testing.T) {
+	db := openStub(t)
+	defer db.Close()
+
+	values := []interface{}{
+		NullTypedID[testUser]{ID: 13587, Valid: true},
+		NullTypedID[testUser]{},
+	}
+	for _, v := range values {
+		if _, err := db.Exec("INSERT", v); err != nil {
+			t.Fatalf("error inserting %#v, %s", v, err)
+		}
+	}
+
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("error querying, %s", err)
+	}
+	defer rows.Close()
+	var i int
+	for ; rows.Next(); i++ {
+		var n NullTypedID[testUser]
+		if err = rows.Scan(&n); err != nil {
+			t.Fatalf("error scanning, %s", err)
+		}
+		if i >= len(values) {
+			t.Fatalf("scanned more than %d rows", len(values))
+		}
+		if n != values[i] {
+			t.Fatalf("row %d: %+v != %+v", i, n, values[i])
+		}
+	}
+	if i != len(values) {
+		t.Fatalf("scanned %d rows, expected %d", i, len(values))
+	}
+
+	b, err := json.Marshal([]NullTypedID[testUser]{{13587, true}, {}})
+	if err != nil {
+		t.Fatalf("error marshaling, %s", err)
+	}
+	if string(b) != `["13587",null]` {
+		t.Fatalf("Got %s, expected %s", b, `["13587",null]`)
+	}
+	var got []NullTypedID[testUser]
+	if err := json.Unmarshal([]byte(`["13587",null,13587]`), &got); err != nil {
+		t.Fatalf("error unmarshaling, %s", err)
+	}
+	expected := []NullTypedID[testUser]{{13587, true}, {}, {13587, true}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Got %+v, expected %+v", got, expected)
+	}
+
+	var n NullTypedID[testUser]
+	if err := n.Scan(int64(-1)); err != ErrNegative || n.Valid {
+		t.Fatalf("Scan(-1) = %+v, %v", n, err)
+	}
+}